@@ -4,14 +4,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/oauth2"
 )
 
+// Number of nodes to request per page of a GraphQL connection.
+const pageSize = 100
+
+// Returned when the requested object does not exist in Buildkite, so that
+// resources can detect objects deleted outside of Terraform.
+var errNotFound = errors.New("object not found in Buildkite")
+
+// Report whether an error means the requested object does not exist.
+func isNotFound(err error) bool {
+	return err == errNotFound
+}
+
 // Client provides a connection to both the Buildkite API and the
 // Buildkite GraphQL interface.
 type Client struct {
@@ -19,48 +35,192 @@ type Client struct {
 	httpAPI *http.Client
 }
 
-func expectToken(decoder *json.Decoder, expected string) error {
-	token, err := decoder.Token()
-	if err != nil {
-		return err
-	}
-	if fmt.Sprintf("%v", token) != expected {
-		return fmt.Errorf("GraphQL expected token '%s', but received '%s' instead", expected, token)
+// PageInfo defines the cursor state of a paginated GraphQL connection.
+type PageInfo struct {
+	EndCursor   string
+	HasNextPage bool
+}
+
+// Quote a string so it can be embedded in a GraphQL query as a string literal.
+func quote(value string) string {
+	literal, _ := json.Marshal(value)
+	return string(literal)
+}
+
+// Enum marks a string to be rendered as a bare GraphQL enum value.
+type enum string
+
+// Render a value as a GraphQL input literal, with object fields in sorted order.
+func literal(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case enum:
+		return string(v)
+	case string:
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case []string:
+		items := make([]string, len(v))
+		for ix, item := range v {
+			items[ix] = quote(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []interface{}:
+		items := make([]string, len(v))
+		for ix, item := range v {
+			items[ix] = literal(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fields := make([]string, len(keys))
+		for ix, key := range keys {
+			fields[ix] = key + ": " + literal(v[key])
+		}
+		return "{ " + strings.Join(fields, ", ") + " }"
+	default:
+		return quote(fmt.Sprintf("%v", v))
 	}
-	return nil
 }
 
+// Query runs a GraphQL query against Buildkite and decodes the contents of
+// the single top-level field in the response into result.
 func (client *Client) Query(result interface{}, query string, args ...interface{}) error {
-	requestBody := fmt.Sprintf(`{ "query": "{ `+query+` }" }`, args...)
+	return client.graphQL(result, fmt.Sprintf("{ "+query+" }", args...))
+}
+
+// Mutate runs a GraphQL mutation against Buildkite and decodes the payload
+// of the single mutation in the response into result.
+func (client *Client) Mutate(result interface{}, mutation string, args ...interface{}) error {
+	return client.graphQL(result, fmt.Sprintf("mutation { "+mutation+" }", args...))
+}
+
+// Send a GraphQL document to Buildkite and decode the single top-level object.
+func (client *Client) graphQL(result interface{}, document string) error {
+	requestBody, err := json.Marshal(map[string]string{
+		"query": document,
+	})
+	if err != nil {
+		return err
+	}
+
 	res, err := client.httpAPI.Post(
 		"https://graphql.buildkite.com/v1",
 		"application/json",
-		strings.NewReader(requestBody))
+		bytes.NewReader(requestBody))
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
 	responseBytes, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return err
 	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GraphQL request failed with status %s: %s", res.Status, responseBytes)
+	}
 
-	decoder := json.NewDecoder(bytes.NewReader(responseBytes))
-	if err := expectToken(decoder, "{"); err != nil {
-		return err
+	var response struct {
+		Data   map[string]json.RawMessage
+		Errors []struct{ Message string }
 	}
-	if err := expectToken(decoder, "data"); err != nil {
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
 		return err
 	}
-	if err := expectToken(decoder, "{"); err != nil {
-		return err
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+	if len(response.Data) != 1 {
+		return fmt.Errorf("GraphQL expected a single top-level object, but received %d instead", len(response.Data))
 	}
+
 	// Skip over the name of the top-level object; we only want the contents
-	if _, err = decoder.Token(); err != nil {
-		return err
+	for _, contents := range response.Data {
+		if string(contents) == "null" {
+			return errNotFound
+		}
+		return json.Unmarshal(contents, result)
+	}
+	return nil
+}
+
+// Send a request to the Buildkite REST API and decode the JSON response into
+// result, unless result is nil; a 404 response is reported as errNotFound.
+func (client *Client) rest(method string, url string, body interface{}, result interface{}) (*http.Response, error) {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequest(method, url, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := client.httpAPI.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer res.Body.Close()
 
-	return decoder.Decode(result)
+	responseBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return res, errNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res, fmt.Errorf("REST request failed with status %s: %s", res.Status, responseBytes)
+	}
+
+	if result != nil && len(responseBytes) > 0 {
+		if err := json.Unmarshal(responseBytes, result); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// Read a paginated GraphQL connection one page at a time; fetch receives the
+// cursor to continue after as a GraphQL literal and returns the page state.
+func (client *Client) queryPages(fetch func(after string) (PageInfo, error)) error {
+	after := "null"
+	for {
+		pageInfo, err := fetch(after)
+		if err != nil {
+			return err
+		}
+		if !pageInfo.HasNextPage {
+			return nil
+		}
+		after = quote(pageInfo.EndCursor)
+	}
+}
+
+// Resolve the organization to operate on, defaulting to the provider organization.
+func (client *Client) organization(slug string) string {
+	if slug == "" {
+		return client.slug
+	}
+	return slug
 }
 
 // NewClient creates a connection to Buildkite.
@@ -0,0 +1,425 @@
+package buildkite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Number of upcoming fire times previewed for a pipeline schedule.
+const pipelineScheduleNextRuns = 5
+
+// How far ahead to look for a fire time before deciding a cronline never fires.
+const cronSearchYears = 5
+
+// Predefined schedules accepted by Buildkite in place of the five cron fields.
+var cronNamedSchedules = map[string]string{
+	"@annually": "0 0 1 1 *",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+	"@midnight": "0 0 * * *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@yearly":   "0 0 1 1 *",
+}
+
+// Returned for cron syntax or time zones that the provider cannot interpret
+// locally but Buildkite may still accept, so they only warrant a warning.
+type cronUnrecognisedError struct {
+	message string
+}
+
+func (err *cronUnrecognisedError) Error() string {
+	return err.message
+}
+
+// Construct an error for cron syntax that is not understood locally.
+func cronUnrecognised(format string, args ...interface{}) error {
+	return &cronUnrecognisedError{message: fmt.Sprintf(format, args...)}
+}
+
+// Report whether an error is about cron syntax that is not understood locally.
+func isCronUnrecognised(err error) bool {
+	_, ok := err.(*cronUnrecognisedError)
+	return ok
+}
+
+// A single cron field, such as the minutes or the days of the week.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	cronMinutes  = cronField{name: "minute", min: 0, max: 59}
+	cronHours    = cronField{name: "hour", min: 0, max: 23}
+	cronDays     = cronField{name: "day of month", min: 1, max: 31}
+	cronMonths   = cronField{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronWeekdays = cronField{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// A parsed cronline, with each field as a bit set of the values it matches.
+type cronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// Days of month and week restrict together only when both are given
+	anyDay     bool
+	anyWeekday bool
+
+	// The last day of the month, from L in the day of month field
+	lastDay bool
+
+	// The nth weekdays of the month, from weekday#n in the day of week field
+	nthWeekdays []cronNthWeekday
+
+	location *time.Location
+}
+
+// The nth occurrence of a weekday in a month, counting from the end when n is negative.
+type cronNthWeekday struct {
+	weekday int
+	n       int
+}
+
+// Parse a cronline in Buildkite's accepted syntax: five cron fields or a
+// predefined schedule such as @daily, optionally followed by a time zone.
+func parseCronline(cronline string) (*cronSchedule, error) {
+	fields := strings.Fields(cronline)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("cronline is empty")
+	}
+
+	if strings.HasPrefix(fields[0], "@") {
+		expression, ok := cronNamedSchedules[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, cronUnrecognised("unrecognised predefined schedule %q", fields[0])
+		}
+		fields = append(strings.Fields(expression), fields[1:]...)
+	}
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("expected five cron fields and an optional time zone, got %q", cronline)
+	}
+	if len(fields) > 6 {
+		return nil, cronUnrecognised("unrecognised cronline %q, expected five cron fields and an optional time zone", cronline)
+	}
+
+	schedule := &cronSchedule{
+		anyDay:     fields[2] == "*" || fields[2] == "?",
+		anyWeekday: fields[4] == "*" || fields[4] == "?",
+		location:   time.UTC,
+	}
+	var err error
+	if schedule.minutes, err = cronMinutes.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = cronHours.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if err = schedule.parseDays(fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = cronMonths.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if err = schedule.parseWeekdays(fields[4]); err != nil {
+		return nil, err
+	}
+	// Sunday may be written as either 0 or 7
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+
+	// Time zones are looked up on the machine running Terraform, which may lack them
+	if len(fields) == 6 {
+		if schedule.location, err = time.LoadLocation(fields[5]); err != nil {
+			return nil, cronUnrecognised("time zone %q is not known on this machine", fields[5])
+		}
+	}
+	return schedule, nil
+}
+
+// Parse the day of month field, where L stands for the last day of the month.
+func (schedule *cronSchedule) parseDays(expression string) error {
+	var items []string
+	for _, item := range strings.Split(expression, ",") {
+		if strings.EqualFold(item, "L") {
+			schedule.lastDay = true
+		} else {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	var err error
+	schedule.days, err = cronDays.parse(strings.Join(items, ","))
+	return err
+}
+
+// Parse the day of week field, where weekday#n stands for the nth such weekday
+// of the month, counting from the end of the month when n is negative or L.
+func (schedule *cronSchedule) parseWeekdays(expression string) error {
+	var items []string
+	for _, item := range strings.Split(expression, ",") {
+		i := strings.Index(item, "#")
+		if i < 0 {
+			items = append(items, item)
+			continue
+		}
+
+		weekday, err := cronWeekdays.value(item[:i])
+		if err != nil {
+			return err
+		}
+		occurrence := item[i+1:]
+		n, err := strconv.Atoi(occurrence)
+		if strings.EqualFold(occurrence, "L") {
+			n, err = -1, nil
+		}
+		if err != nil {
+			return cronUnrecognised("unrecognised occurrence %q in %s field", occurrence, cronWeekdays.name)
+		}
+		if n == 0 || n < -5 || n > 5 {
+			return fmt.Errorf("invalid occurrence %q in %s field, expected 1-5, -1 to -5 or L", occurrence, cronWeekdays.name)
+		}
+		schedule.nthWeekdays = append(schedule.nthWeekdays, cronNthWeekday{weekday: weekday % 7, n: n})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	var err error
+	schedule.weekdays, err = cronWeekdays.parse(strings.Join(items, ","))
+	return err
+}
+
+// Parse a comma-separated list of values, ranges and steps into a bit set.
+func (field cronField) parse(expression string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expression, ",") {
+		rangeExpression, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			value, err := strconv.Atoi(item[i+1:])
+			if err != nil {
+				return 0, cronUnrecognised("unrecognised step %q in %s field", item[i+1:], field.name)
+			}
+			if value < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", item[i+1:], field.name)
+			}
+			rangeExpression, step = item[:i], value
+		}
+
+		var low, high int
+		switch {
+		case rangeExpression == "*" || rangeExpression == "?":
+			low, high = field.min, field.max
+		case strings.Contains(rangeExpression, "-"):
+			bounds := strings.SplitN(rangeExpression, "-", 2)
+			var err error
+			if low, err = field.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = field.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			// Some cron parsers wrap such ranges around the end of the field
+			if low > high {
+				return 0, cronUnrecognised("unrecognised range %q in %s field", rangeExpression, field.name)
+			}
+		default:
+			var err error
+			if low, err = field.value(rangeExpression); err != nil {
+				return 0, err
+			}
+			high = low
+			// A stepped single value runs from that value to the end of the field
+			if step > 1 {
+				high = field.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// Parse a single number or name within the bounds of the field.
+func (field cronField) value(expression string) (int, error) {
+	for i, name := range field.names {
+		if strings.ToLower(expression) == name {
+			return i + field.min, nil
+		}
+	}
+	value, err := strconv.Atoi(expression)
+	if err != nil {
+		return 0, cronUnrecognised("unrecognised value %q in %s field", expression, field.name)
+	}
+	if value < field.min || value > field.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", expression, field.name, field.min, field.max)
+	}
+	return value, nil
+}
+
+// Check whether the schedule fires on the day of the given time.
+func (schedule *cronSchedule) matchesDay(t time.Time) bool {
+	day := schedule.days&(1<<uint(t.Day())) != 0 || (schedule.lastDay && t.AddDate(0, 0, 1).Day() == 1)
+	weekday := schedule.weekdays&(1<<uint(t.Weekday())) != 0
+	for _, nth := range schedule.nthWeekdays {
+		weekday = weekday || nth.matches(t)
+	}
+	switch {
+	case schedule.anyDay && schedule.anyWeekday:
+		return true
+	case schedule.anyDay:
+		return weekday
+	case schedule.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// Check whether the day of the given time is the nth occurrence of the weekday in its month.
+func (nth cronNthWeekday) matches(t time.Time) bool {
+	if int(t.Weekday()) != nth.weekday {
+		return false
+	}
+	if nth.n > 0 {
+		return (t.Day()-1)/7+1 == nth.n
+	}
+	daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return (daysInMonth-t.Day())/7+1 == -nth.n
+}
+
+// The hours of a schedule that runs every hour, which also runs during a repeated hour.
+const cronEveryHour = 1<<24 - 1
+
+// Find the first fire time strictly after the given time, or the zero time
+// when the schedule never fires within the search window.
+func (schedule *cronSchedule) next(after time.Time) time.Time {
+	t := after.In(schedule.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case schedule.months&(1<<uint(t.Month())) == 0:
+			t = cronAdvance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, schedule.location))
+		case !schedule.matchesDay(t):
+			t = cronAdvance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, schedule.location))
+		case schedule.hours&(1<<uint(t.Hour())) == 0:
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case schedule.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		case schedule.hours != cronEveryHour && repeatedLocalTime(t):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Move on to the given local time, or by a minute when a daylight saving
+// transition maps that local time to the past.
+func cronAdvance(t time.Time, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Minute)
+}
+
+// Report whether a local time already occurred earlier, because the clocks
+// were set back for daylight saving; schedules only fire the first time.
+func repeatedLocalTime(t time.Time) bool {
+	_, offset := t.Zone()
+	_, earlierOffset := t.Add(-3 * time.Hour).Zone()
+	if earlierOffset <= offset {
+		return false
+	}
+	earlier := t.Add(-time.Duration(earlierOffset-offset) * time.Second)
+	return earlier.Hour() == t.Hour() && earlier.Minute() == t.Minute()
+}
+
+// List the next fire times of a cronline after the given time.
+func cronlineNextRuns(cronline string, after time.Time, count int) ([]string, error) {
+	schedule, err := parseCronline(cronline)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []string{}
+	for len(runs) < count {
+		after = schedule.next(after)
+		if after.IsZero() {
+			break
+		}
+		runs = append(runs, after.Format(time.RFC3339))
+	}
+	return runs, nil
+}
+
+// List the next fire times of a cronline starting with the next build Buildkite
+// has scheduled, or after now when there is none, so the list only moves on
+// once that build is due; schedules that cannot be previewed list no runs.
+func cronlineNextRunsFrom(cronline string, nextBuildAt string, now time.Time, count int) []string {
+	after := now
+	if next, err := time.Parse(time.RFC3339, nextBuildAt); err == nil {
+		after = next.Add(-time.Second)
+	}
+	runs, err := cronlineNextRuns(cronline, after, count)
+	if err != nil {
+		return []string{}
+	}
+	return runs
+}
+
+// Validate the cronline of a pipeline schedule at plan time; syntax that is
+// not understood locally is left for Buildkite to judge.
+func validateCronline(value interface{}, key string) (warnings []string, errors []error) {
+	schedule, err := parseCronline(value.(string))
+	if isCronUnrecognised(err) {
+		return []string{fmt.Sprintf("%s: %s, so upcoming runs cannot be previewed", key, err)}, nil
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", key, err)}
+	}
+	if schedule.next(time.Now()).IsZero() {
+		warnings = append(warnings, fmt.Sprintf("%s: %q never fires", key, value.(string)))
+	}
+	return
+}
+
+// Preview the upcoming fire times of a schedule whenever its cronline or
+// enabled state changes, so the plan shows when it will trigger.
+func customizePipelineScheduleDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("cronline") && !d.HasChange("enabled") {
+		return nil
+	}
+	if !d.NewValueKnown("cronline") || !d.NewValueKnown("enabled") {
+		return d.SetNewComputed("next_runs")
+	}
+
+	runs := []string{}
+	if d.Get("enabled").(bool) {
+		var err error
+		runs, err = cronlineNextRuns(d.Get("cronline").(string), time.Now(), pipelineScheduleNextRuns)
+		if isCronUnrecognised(err) {
+			runs = []string{}
+		} else if err != nil {
+			return err
+		}
+	}
+	return d.SetNew("next_runs", runs)
+}
@@ -0,0 +1,223 @@
+package buildkite
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCronline(t *testing.T) {
+	cases := []struct {
+		cronline     string
+		unrecognised bool
+		invalid      bool
+	}{
+		{cronline: "0 9 * * 1-5"},
+		{cronline: "*/15 * * * *"},
+		{cronline: "@daily"},
+		{cronline: "@weekly UTC"},
+		{cronline: "0 0 L * *"},
+		{cronline: "0 0 1,L * *"},
+		{cronline: "0 9 * * mon#1"},
+		{cronline: "0 9 * * fri#L"},
+		{cronline: "0 9 * * 5#-2"},
+		{cronline: "0 9 * jan-mar sun,7"},
+		{cronline: "0 9 * *", invalid: true},
+		{cronline: "60 * * * *", invalid: true},
+		{cronline: "*/0 * * * *", invalid: true},
+		{cronline: "0 9 * * mon#6", invalid: true},
+		{cronline: "0 0 L-2 * *", unrecognised: true},
+		{cronline: "0 9 * * mon%2", unrecognised: true},
+		{cronline: "0 22-2 * * *", unrecognised: true},
+		{cronline: "@reboot", unrecognised: true},
+		{cronline: "0 9 * * * Mars/Olympus_Mons", unrecognised: true},
+		{cronline: "0 0 9 * * * UTC", unrecognised: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.cronline, func(t *testing.T) {
+			_, err := parseCronline(c.cronline)
+			switch {
+			case c.unrecognised:
+				if !isCronUnrecognised(err) {
+					t.Errorf("expected an unrecognised cronline, got %v", err)
+				}
+			case c.invalid:
+				if err == nil || isCronUnrecognised(err) {
+					t.Errorf("expected an invalid cronline, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Errorf("expected a valid cronline, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	cases := []struct {
+		name     string
+		cronline string
+		after    string
+		next     string
+	}{
+		{
+			name:     "weekdays",
+			cronline: "0 9 * * 1-5",
+			after:    "2021-01-01T10:00:00Z",
+			next:     "2021-01-04T09:00:00Z",
+		},
+		{
+			name:     "last day of month",
+			cronline: "0 0 L * *",
+			after:    "2021-02-10T00:00:00Z",
+			next:     "2021-02-28T00:00:00Z",
+		},
+		{
+			name:     "last day of leap month",
+			cronline: "0 0 L * *",
+			after:    "2024-02-10T00:00:00Z",
+			next:     "2024-02-29T00:00:00Z",
+		},
+		{
+			name:     "first monday",
+			cronline: "0 9 * * mon#1",
+			after:    "2021-03-01T10:00:00Z",
+			next:     "2021-04-05T09:00:00Z",
+		},
+		{
+			name:     "last friday",
+			cronline: "0 9 * * fri#L",
+			after:    "2021-04-01T00:00:00Z",
+			next:     "2021-04-30T09:00:00Z",
+		},
+		{
+			name:     "day of month or week",
+			cronline: "0 9 13 * 5",
+			after:    "2021-08-01T00:00:00Z",
+			next:     "2021-08-06T09:00:00Z",
+		},
+		{
+			name:     "never",
+			cronline: "0 0 30 2 *",
+			after:    "2021-01-01T00:00:00Z",
+			next:     "",
+		},
+		{
+			name:     "time zone",
+			cronline: "0 9 * * * Europe/Amsterdam",
+			after:    "2021-06-01T12:00:00Z",
+			next:     "2021-06-02T09:00:00+02:00",
+		},
+		{
+			name:     "skipped by spring forward",
+			cronline: "30 2 * * * America/New_York",
+			after:    "2021-03-13T12:00:00-05:00",
+			next:     "2021-03-15T02:30:00-04:00",
+		},
+		{
+			name:     "hourly across spring forward",
+			cronline: "0 * * * * America/New_York",
+			after:    "2021-03-14T01:30:00-05:00",
+			next:     "2021-03-14T03:00:00-04:00",
+		},
+		{
+			name:     "first of repeated times",
+			cronline: "30 1 * * * America/New_York",
+			after:    "2021-11-07T01:00:00-04:00",
+			next:     "2021-11-07T01:30:00-04:00",
+		},
+		{
+			name:     "repeated by fall back",
+			cronline: "30 1 * * * America/New_York",
+			after:    "2021-11-07T01:30:00-04:00",
+			next:     "2021-11-08T01:30:00-05:00",
+		},
+		{
+			name:     "hourly across fall back",
+			cronline: "0 * * * * America/New_York",
+			after:    "2021-11-07T00:30:00-04:00",
+			next:     "2021-11-07T01:00:00-04:00",
+		},
+		{
+			name:     "hourly in repeated hour",
+			cronline: "0 * * * * America/New_York",
+			after:    "2021-11-07T01:30:00-04:00",
+			next:     "2021-11-07T01:00:00-05:00",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schedule, err := parseCronline(c.cronline)
+			if isCronUnrecognised(err) {
+				t.Skipf("time zone not available: %s", err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := time.Parse(time.RFC3339, c.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			next := ""
+			if found := schedule.next(after); !found.IsZero() {
+				next = found.Format(time.RFC3339)
+			}
+			if next != c.next {
+				t.Errorf("expected %q, got %q", c.next, next)
+			}
+		})
+	}
+}
+
+func TestCronlineNextRunsFrom(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 17, 0, 0, time.UTC)
+	later := now.Add(25 * time.Minute)
+	cases := []struct {
+		name        string
+		cronline    string
+		nextBuildAt string
+		now         time.Time
+		runs        []string
+	}{
+		{
+			name:        "anchored to the next build",
+			cronline:    "*/30 * * * *",
+			nextBuildAt: "2021-03-01T10:30:00Z",
+			now:         now,
+			runs:        []string{"2021-03-01T10:30:00Z", "2021-03-01T11:00:00Z"},
+		},
+		{
+			name:        "stable until the next build is due",
+			cronline:    "*/30 * * * *",
+			nextBuildAt: "2021-03-01T10:30:00Z",
+			now:         later,
+			runs:        []string{"2021-03-01T10:30:00Z", "2021-03-01T11:00:00Z"},
+		},
+		{
+			name:     "no next build",
+			cronline: "*/30 * * * *",
+			now:      later,
+			runs:     []string{"2021-03-01T11:00:00Z", "2021-03-01T11:30:00Z"},
+		},
+		{
+			name:        "unrecognised",
+			cronline:    "@fortnightly",
+			nextBuildAt: "2021-03-01T10:30:00Z",
+			now:         now,
+			runs:        []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			runs := cronlineNextRunsFrom(c.cronline, c.nextBuildAt, c.now, 2)
+			if strings.Join(runs, " ") != strings.Join(c.runs, " ") || len(runs) != len(c.runs) {
+				t.Errorf("expected %q, got %q", c.runs, runs)
+			}
+		})
+	}
+}
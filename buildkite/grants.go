@@ -0,0 +1,98 @@
+package buildkite
+
+// A grant gives a subject, such as a user or a team, a level of access to a
+// team or pipeline, such as a member role or a pipeline access level.
+type grant struct {
+	id      string
+	level   string
+	subject string
+}
+
+// The API operations that manage the grants of a single team or pipeline, so
+// that resources can reconcile them the same way whatever is being granted.
+type grantReconciler struct {
+	read   func() ([]grant, error)
+	create func(subject string, level string) error
+	update func(id string, level string) error
+	delete func(id string) error
+}
+
+// Make the live grants match the given levels by subject, revoking a grant
+// missing from levels only if revoke returns true for its subject.
+func (r grantReconciler) apply(levels map[string]string, revoke func(subject string) bool) error {
+	grants, err := r.read()
+	if err != nil {
+		return err
+	}
+	existing := map[string]grant{}
+	for _, grant := range grants {
+		existing[grant.subject] = grant
+	}
+
+	for _, grant := range grants {
+		if _, keep := levels[grant.subject]; keep || !revoke(grant.subject) {
+			continue
+		}
+		if err := r.delete(grant.id); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	for _, subject := range sortedKeys(levels) {
+		level := levels[subject]
+		grant, ok := existing[subject]
+		switch {
+		case !ok:
+			err = r.create(subject, level)
+		case grant.level != level:
+			err = r.update(grant.id, level)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Revoke the live grants to the given subjects, leaving any other grants.
+func (r grantReconciler) revoke(subjects map[string]string) error {
+	grants, err := r.read()
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range grants {
+		if _, ok := subjects[grant.subject]; !ok {
+			continue
+		}
+		if err := r.delete(grant.id); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Convert grants to set elements with the given attribute names for the
+// level and subject, keeping only grants for which keep returns true.
+func grantSet(grants []grant, levelKey string, subjectKey string, keep func(subject string) bool) []interface{} {
+	list := []interface{}{}
+	for _, grant := range grants {
+		if !keep(grant.subject) {
+			continue
+		}
+		list = append(list, map[string]interface{}{
+			levelKey:   grant.level,
+			subjectKey: grant.subject,
+		})
+	}
+	return list
+}
+
+// Match every subject, for resources that manage all grants of an entity.
+func allGrants(subject string) bool {
+	return true
+}
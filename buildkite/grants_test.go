@@ -0,0 +1,69 @@
+package buildkite
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGrantReconcilerApply(t *testing.T) {
+	existing := []grant{
+		{id: "g1", level: "MEMBER", subject: "alice"},
+		{id: "g2", level: "MEMBER", subject: "bob"},
+		{id: "g3", level: "MAINTAINER", subject: "carol"},
+	}
+	cases := []struct {
+		name     string
+		levels   map[string]string
+		revoke   func(string) bool
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			levels:   map[string]string{"alice": "MEMBER", "bob": "MEMBER", "carol": "MAINTAINER"},
+			revoke:   allGrants,
+			expected: nil,
+		},
+		{
+			name:     "authoritative",
+			levels:   map[string]string{"alice": "MAINTAINER", "dave": "MEMBER"},
+			revoke:   allGrants,
+			expected: []string{"delete g2", "delete g3", "update g1 MAINTAINER", "create dave MEMBER"},
+		},
+		{
+			name:     "only revoke managed subjects",
+			levels:   map[string]string{"alice": "MEMBER"},
+			revoke:   func(subject string) bool { return subject == "bob" },
+			expected: []string{"delete g2"},
+		},
+	}
+
+	for _, c := range cases {
+		var calls []string
+		reconciler := grantReconciler{
+			read: func() ([]grant, error) {
+				return existing, nil
+			},
+			create: func(subject string, level string) error {
+				calls = append(calls, fmt.Sprintf("create %s %s", subject, level))
+				return nil
+			},
+			update: func(id string, level string) error {
+				calls = append(calls, fmt.Sprintf("update %s %s", id, level))
+				return nil
+			},
+			delete: func(id string) error {
+				calls = append(calls, fmt.Sprintf("delete %s", id))
+				return nil
+			},
+		}
+
+		if err := reconciler.apply(c.levels, c.revoke); err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(calls, c.expected) {
+			t.Errorf("%s: expected calls %q, got %q", c.name, c.expected, calls)
+		}
+	}
+}
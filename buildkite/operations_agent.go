@@ -2,9 +2,9 @@ package buildkite
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -45,10 +45,47 @@ func dataSourceAgentsRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting agents: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID("agents", client.organization(slug)))
 	return err
 }
 
+// Define a Terraform data source for a single agent.
+func dataSourceAgent() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAgentRead,
+
+		Schema: schemaDataSourceLookup(schemaAgent(DataSourceFullEntity), "name", "uuid"),
+	}
+}
+
+// Look up a single agent from the Buildkite API and convert to the Terraform schema.
+func dataSourceAgentRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := d.Get("organization_slug").(string)
+
+	agents, err := client.readAgents(slug)
+	if err != nil {
+		return err
+	}
+
+	list := []map[string]interface{}{}
+	for _, item := range agents {
+		list = append(list, item.convert(DataSourceFullEntity))
+	}
+
+	agent, err := findEntity(d, "agent", list, "name", "uuid")
+	if err != nil {
+		return err
+	}
+	if err := setResourceData(d, agent); err != nil {
+		return err
+	}
+
+	d.SetId(agent["id"].(string))
+	return nil
+}
+
 // Construct a Terraform schema definition for a list of agents.
 func schemaAgentList(mode SchemaMode) *schema.Schema {
 	return &schema.Schema{
@@ -152,6 +189,7 @@ type AgentList struct {
 	Edges []struct {
 		Node Agent
 	}
+	PageInfo PageInfo
 }
 
 // Convert a Buildkite API type to a Terraform structure.
@@ -164,8 +202,12 @@ func (source *Agent) convert(mode SchemaMode) map[string]interface{} {
 	case DataSourceFullEntity:
 		metaData := make(map[string]string)
 		for _, e := range source.MetaData {
-			parts := strings.Split(string(e), "=")
-			metaData[parts[0]] = parts[1]
+			parts := strings.SplitN(e, "=", 2)
+			if len(parts) == 2 {
+				metaData[parts[0]] = parts[1]
+			} else {
+				metaData[parts[0]] = ""
+			}
 		}
 
 		return map[string]interface{}{
@@ -195,53 +237,149 @@ func (source *AgentList) convert(mode SchemaMode) (result []interface{}) {
 	return
 }
 
-func (client *Client) readAgents(slug string) ([]Agent, error) {
-	return nil, nil
-	/*
-		coalescedSlug := slug
-		if coalescedSlug == "" {
-			coalescedSlug = client.slug
-		}
+// Retrieve the fields of an agent.
+const fieldsAgent = "hostname " +
+	"id " +
+	"ipAddress " +
+	"isDeprecated " +
+	"metaData " +
+	"name " +
+	"operatingSystem { name } " +
+	"public " +
+	"userAgent " +
+	"uuid " +
+	"version " +
+	"versionHasKnownIssues"
 
-		var countQuery struct {
-			Organization struct {
-				Agents struct {
-					Count graphql.Int
-				}
-			} `graphql:"organization(slug: $slug)"`
-		}
-		countVars := map[string]interface{}{
-			"slug": graphql.ID(coalescedSlug),
-		}
-		err := client.graph.Query(context.Background(), &countQuery, countVars)
+// Retrieve one page of agents for an organization.
+const queryOrganizationAgents = "organization(slug: %s) { " +
+	"agents(first: %d, after: %s) { " +
+	"edges { node { " + fieldsAgent + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Read a list of all connected agents for an organization from the Buildkite API.
+func (client *Client) readAgents(slug string) ([]Agent, error) {
+	var agents []Agent
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Agents AgentList }
+		err := client.Query(&page, queryOrganizationAgents,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
 		if err != nil {
-			return nil, err
+			return PageInfo{}, err
 		}
 
-		var query struct {
-			Organization struct {
-				Agents struct {
-					Edges []struct {
-						Node Agent
-					}
-				} `graphql:"agents(first: $first)"`
-			} `graphql:"organization(slug: $slug)"`
-		}
-		vars := map[string]interface{}{
-			"slug":  graphql.ID(coalescedSlug),
-			"first": countQuery.Organization.Agents.Count,
+		for _, edge := range page.Agents.Edges {
+			agents = append(agents, edge.Node)
 		}
+		return page.Agents.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].UUID < agents[j].UUID
+	})
+	return agents, nil
+}
+
+// AgentToken defines the properties on the Buildkite API to map to Terraform.
+type AgentToken struct {
+	CreatedAt   string
+	CreatedBy   User
+	Description string
+	ID          string
+	RevokedAt   string
+	UUID        string
+}
+
+// AgentTokenList defines the properties on the Buildkite API to map to Terraform.
+type AgentTokenList struct {
+	Count int
+	Edges []struct {
+		Node AgentToken
+	}
+	PageInfo PageInfo
+}
+
+// Retrieve the fields of an agent token.
+const fieldsAgentToken = "createdAt " +
+	"createdBy { " + fieldsUser + " } " +
+	"description " +
+	"id " +
+	"revokedAt " +
+	"uuid"
+
+// Retrieve an agent token by its GraphQL identifier.
+const queryNodeAgentToken = "node(id: %s) { ... on AgentToken { " + fieldsAgentToken + " } }"
 
-		err = client.graphQL.Query(context.Background(), &query, vars)
+// Retrieve one page of agent tokens for an organization.
+const queryOrganizationAgentTokens = "organization(slug: %s) { " +
+	"agentTokens(first: %d, after: %s) { " +
+	"edges { node { " + fieldsAgentToken + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Create an agent token from a GraphQL input object.
+const mutationAgentTokenCreate = "agentTokenCreate(input: %s) { " +
+	"agentTokenEdge { node { " + fieldsAgentToken + " } } tokenValue }"
+
+// Revoke an agent token by its GraphQL identifier.
+const mutationAgentTokenRevoke = "agentTokenRevoke(input: { id: %s, reason: %s }) { " +
+	"agentToken { " + fieldsAgentToken + " } }"
+
+// Read a single agent token from the Buildkite API by its GraphQL identifier.
+func (client *Client) readAgentToken(id string) (*AgentToken, error) {
+	var token AgentToken
+	if err := client.Query(&token, queryNodeAgentToken, quote(id)); err != nil {
+		return nil, err
+	}
+	if token.ID == "" {
+		return nil, errNotFound
+	}
+	return &token, nil
+}
+
+// Read a list of all agent tokens for an organization from the Buildkite API.
+func (client *Client) readAgentTokens(slug string) ([]AgentToken, error) {
+	var tokens []AgentToken
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ AgentTokens AgentTokenList }
+		err := client.Query(&page, queryOrganizationAgentTokens,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
 		if err != nil {
-			return nil, err
+			return PageInfo{}, err
 		}
 
-		var agents []Agent
-		edges := &query.Organization.Agents.Edges
-		for _, agent := range *edges {
-			agents = append(agents, agent.Node)
+		for _, edge := range page.AgentTokens.Edges {
+			tokens = append(tokens, edge.Node)
 		}
-		return agents, nil
-	*/
+		return page.AgentTokens.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Create an agent token through the Buildkite API, returning the token value
+// that is only available at creation.
+func (client *Client) createAgentToken(input map[string]interface{}) (*AgentToken, string, error) {
+	var payload struct {
+		AgentTokenEdge struct{ Node AgentToken }
+		TokenValue     string
+	}
+	if err := client.Mutate(&payload, mutationAgentTokenCreate, literal(input)); err != nil {
+		return nil, "", err
+	}
+	return &payload.AgentTokenEdge.Node, payload.TokenValue, nil
+}
+
+// Revoke an agent token through the Buildkite API.
+func (client *Client) revokeAgentToken(id string, reason string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationAgentTokenRevoke, quote(id), quote(reason))
 }
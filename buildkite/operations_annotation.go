@@ -0,0 +1,218 @@
+package buildkite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// Define a Terraform data source for the annotations of a build.
+func dataSourceBuildAnnotations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildAnnotationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"build_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the build to list the annotations of",
+				Required:    true,
+			},
+			"context": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Only return annotations with this context",
+				Optional:    true,
+			},
+			"style": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Only return annotations with this style",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"DEFAULT", "ERROR", "INFO", "SUCCESS", "WARNING"}, true),
+			},
+
+			"annotations": schemaAnnotationList(DataSourceFullEntity),
+		},
+	}
+}
+
+// Read the annotations of a build from the Buildkite API and convert to the Terraform schema.
+func dataSourceBuildAnnotationsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	annotations, err := client.readBuildAnnotations(d.Get("build_id").(string))
+	if err != nil {
+		return err
+	}
+
+	context := d.Get("context").(string)
+	style := d.Get("style").(string)
+
+	list := []interface{}{}
+	uuids := []string{}
+	for _, item := range annotations {
+		if context != "" && item.Context != context {
+			continue
+		}
+		if style != "" && !strings.EqualFold(item.Style, style) {
+			continue
+		}
+		list = append(list, item.convert(DataSourceFullEntity))
+		uuids = append(uuids, item.UUID)
+	}
+
+	if err := d.Set("annotations", list); err != nil {
+		return fmt.Errorf("error setting annotations: %s", err)
+	}
+
+	d.SetId(dataSourceID(uuids...))
+	return nil
+}
+
+// Construct a Terraform schema definition for a list of annotations.
+func schemaAnnotationList(mode SchemaMode) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of Buildkite build annotations",
+		Elem:        schemaAnnotation(mode),
+	}
+}
+
+// Construct a Terraform schema definition for an annotation.
+func schemaAnnotation(mode SchemaMode) *schema.Resource {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	case DataSourceFullEntity:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"body_html": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Body of the annotation rendered as HTML",
+					Computed:    true,
+				},
+				"body_text": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Body of the annotation as written by the build",
+					Computed:    true,
+				},
+				"context": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"created_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"style": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"updated_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	default:
+		return &schema.Resource{}
+	}
+}
+
+// Annotation defines the properties on the Buildkite API to map to Terraform.
+type Annotation struct {
+	Body struct {
+		HTML string
+		Text string
+	}
+	Context   string
+	CreatedAt string
+	ID        string
+	Style     string
+	UpdatedAt string
+	UUID      string
+}
+
+// AnnotationList defines the properties on the Buildkite API to map to Terraform.
+type AnnotationList struct {
+	Count int
+	Edges []struct {
+		Node Annotation
+	}
+	PageInfo PageInfo
+}
+
+// Convert a Buildkite API type to a Terraform structure.
+func (source *Annotation) convert(mode SchemaMode) map[string]interface{} {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return map[string]interface{}{
+			"uuid": source.UUID,
+		}
+	case DataSourceFullEntity:
+		return map[string]interface{}{
+			"body_html":  source.Body.HTML,
+			"body_text":  source.Body.Text,
+			"context":    source.Context,
+			"created_at": source.CreatedAt,
+			"id":         source.ID,
+			"style":      source.Style,
+			"updated_at": source.UpdatedAt,
+			"uuid":       source.UUID,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// Retrieve the fields of an annotation.
+const fieldsAnnotation = "body { html text } " +
+	"context " +
+	"createdAt " +
+	"id " +
+	"style " +
+	"updatedAt " +
+	"uuid"
+
+// Retrieve one page of the annotations of a build by its GraphQL identifier.
+const queryNodeBuildAnnotations = "node(id: %s) { ... on Build { " +
+	"annotations(first: %d, after: %s) { " +
+	"edges { node { " + fieldsAnnotation + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Read the annotations of a build from the Buildkite API.
+func (client *Client) readBuildAnnotations(id string) ([]Annotation, error) {
+	var annotations []Annotation
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Annotations AnnotationList }
+		err := client.Query(&page, queryNodeBuildAnnotations, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Annotations.Edges {
+			annotations = append(annotations, edge.Node)
+		}
+		return page.Annotations.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
@@ -0,0 +1,381 @@
+package buildkite
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Define a Terraform data source for the artifacts of a build or job.
+func dataSourceBuildArtifacts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildArtifactsRead,
+
+		Schema: map[string]*schema.Schema{
+			"artifacts": schemaArtifactList(DataSourceFullEntity),
+			"build_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "GraphQL identifier of the build to list the artifacts of",
+				Optional:     true,
+				ExactlyOneOf: []string{"build_id", "job_id"},
+			},
+			"download_path": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Local directory to download the matching artifacts into, verifying each against its SHA-1",
+				Optional:    true,
+			},
+			"glob": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Glob pattern the artifact paths must match, where * does not match /",
+				Optional:     true,
+				ValidateFunc: validateArtifactGlob,
+			},
+			"job_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "GraphQL identifier of the command job to list the artifacts of",
+				Optional:     true,
+				ExactlyOneOf: []string{"build_id", "job_id"},
+			},
+		},
+	}
+}
+
+// Read the artifacts of a build or job from the Buildkite API, downloading them when requested.
+func dataSourceBuildArtifactsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	var artifacts []Artifact
+	var err error
+	if id, ok := d.GetOk("build_id"); ok {
+		artifacts, err = client.readBuildArtifacts(id.(string))
+	} else {
+		artifacts, err = client.readJobArtifacts(d.Get("job_id").(string))
+	}
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Path != artifacts[j].Path {
+			return artifacts[i].Path < artifacts[j].Path
+		}
+		return artifacts[i].UUID < artifacts[j].UUID
+	})
+
+	glob := d.Get("glob").(string)
+	directory := d.Get("download_path").(string)
+
+	list := []interface{}{}
+	uuids := []string{}
+	for _, item := range artifacts {
+		if glob != "" {
+			if matched, _ := path.Match(glob, item.Path); !matched {
+				continue
+			}
+		}
+
+		artifact := item.convert(DataSourceFullEntity)
+		if directory != "" {
+			localPath, err := downloadArtifact(&item, directory)
+			if err != nil {
+				return err
+			}
+			artifact["local_path"] = localPath
+		}
+
+		list = append(list, artifact)
+		uuids = append(uuids, item.UUID)
+	}
+
+	if err := d.Set("artifacts", list); err != nil {
+		return fmt.Errorf("error setting artifacts: %s", err)
+	}
+
+	d.SetId(dataSourceID(uuids...))
+	return nil
+}
+
+// Validate an artifact glob pattern at plan time.
+func validateArtifactGlob(value interface{}, key string) (warnings []string, errors []error) {
+	if _, err := path.Match(value.(string), ""); err != nil {
+		errors = append(errors, fmt.Errorf("%s: invalid glob %q: %s", key, value.(string), err))
+	}
+	return
+}
+
+// Construct a Terraform schema definition for a list of artifacts.
+func schemaArtifactList(mode SchemaMode) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of Buildkite build artifacts",
+		Elem:        schemaArtifact(mode),
+	}
+}
+
+// Construct a Terraform schema definition for an artifact.
+func schemaArtifact(mode SchemaMode) *schema.Resource {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	case DataSourceFullEntity:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"download_url": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Short-lived URL to download the artifact from",
+					Computed:    true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"job_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"local_path": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Path of the verified download, when download_path is set",
+					Computed:    true,
+				},
+				"mime_type": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"path": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"sha1sum": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"size": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"state": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	default:
+		return &schema.Resource{}
+	}
+}
+
+// Artifact defines the properties on the Buildkite API to map to Terraform.
+type Artifact struct {
+	DownloadURL string
+	ID          string
+	Job         struct{ ID string }
+	MimeType    string
+	Path        string
+	Sha1Sum     string
+	Size        int
+	State       string
+	UUID        string
+}
+
+// ArtifactList defines the properties on the Buildkite API to map to Terraform.
+type ArtifactList struct {
+	Count int
+	Edges []struct {
+		Node Artifact
+	}
+	PageInfo PageInfo
+}
+
+// Convert a Buildkite API type to a Terraform structure.
+func (source *Artifact) convert(mode SchemaMode) map[string]interface{} {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return map[string]interface{}{
+			"uuid": source.UUID,
+		}
+	case DataSourceFullEntity:
+		return map[string]interface{}{
+			"download_url": source.DownloadURL,
+			"id":           source.ID,
+			"job_id":       source.Job.ID,
+			"local_path":   "",
+			"mime_type":    source.MimeType,
+			"path":         source.Path,
+			"sha1sum":      source.Sha1Sum,
+			"size":         source.Size,
+			"state":        source.State,
+			"uuid":         source.UUID,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// Retrieve the fields of an artifact.
+const fieldsArtifact = "downloadURL " +
+	"id " +
+	"job { id } " +
+	"mimeType " +
+	"path " +
+	"sha1sum " +
+	"size " +
+	"state " +
+	"uuid"
+
+// Retrieve one page of the command jobs of a build by its GraphQL identifier.
+const queryNodeBuildCommandJobs = "node(id: %s) { ... on Build { " +
+	"jobs(first: %d, after: %s, type: [COMMAND]) { " +
+	"edges { node { ... on JobTypeCommand { id } } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Retrieve one page of the artifacts of a command job by its GraphQL identifier.
+const queryNodeJobArtifacts = "node(id: %s) { ... on JobTypeCommand { " +
+	"artifacts(first: %d, after: %s) { " +
+	"edges { node { " + fieldsArtifact + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Read the artifacts of every command job of a build from the Buildkite API.
+func (client *Client) readBuildArtifacts(id string) ([]Artifact, error) {
+	var jobs []string
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Jobs JobList }
+		err := client.Query(&page, queryNodeBuildCommandJobs, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Jobs.Edges {
+			jobs = append(jobs, edge.Node.ID)
+		}
+		return page.Jobs.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []Artifact
+	for _, job := range jobs {
+		items, err := client.readJobArtifacts(job)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, items...)
+	}
+	return artifacts, nil
+}
+
+// Read the artifacts of a command job from the Buildkite API.
+func (client *Client) readJobArtifacts(id string) ([]Artifact, error) {
+	var artifacts []Artifact
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Artifacts ArtifactList }
+		err := client.Query(&page, queryNodeJobArtifacts, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Artifacts.Edges {
+			artifacts = append(artifacts, edge.Node)
+		}
+		return page.Artifacts.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// Client for artifact downloads, which go straight to storage rather than
+// through the API; the timeout covers the whole transfer of one artifact.
+var artifactDownloadClient = &http.Client{Timeout: 10 * time.Minute}
+
+// Download an artifact below a local directory at its artifact path, keeping
+// an existing file that already has the expected SHA-1, and return the local path.
+func downloadArtifact(artifact *Artifact, directory string) (string, error) {
+	target := filepath.Join(directory, filepath.FromSlash(artifact.Path))
+	if relative, err := filepath.Rel(directory, target); err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("artifact path %q is outside of the download path", artifact.Path)
+	}
+	if artifact.State != "FINISHED" {
+		return "", fmt.Errorf("artifact %q is %s and cannot be downloaded", artifact.Path, artifact.State)
+	}
+
+	if sum, err := fileSha1Sum(target); err == nil && sum == artifact.Sha1Sum {
+		return target, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	// The download URL is pre-signed, so the API token must not be sent with it
+	res, err := artifactDownloadClient.Get(artifact.DownloadURL)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download of artifact %q failed with status %s", artifact.Path, res.Status)
+	}
+
+	hash := sha1.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), res.Body); err != nil {
+		return "", err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != artifact.Sha1Sum {
+		return "", fmt.Errorf("artifact %q failed verification, expected SHA-1 %s but downloaded %s", artifact.Path, artifact.Sha1Sum, sum)
+	}
+
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(file.Name(), target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// Compute the hex SHA-1 of a local file.
+func fileSha1Sum(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha1.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,342 @@
+package buildkite
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// Audit event and subject types as named by the GraphQL enums.
+var auditEventEnumFormat = regexp.MustCompile(`^[A-Z_]+$`)
+
+// Define a Terraform data source for the audit events of an organization.
+func dataSourceAuditEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAuditEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"actor_uuid": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Only return events performed by the actor with this UUID",
+				Optional:    true,
+			},
+			"event_types": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Only return events of these types, such as PIPELINE_UPDATED",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(auditEventEnumFormat, "must be an audit event type such as PIPELINE_UPDATED"),
+				},
+			},
+			"limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of events to return, so a broad filter does not page through the whole audit log",
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"occurred_at_from": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Only return events that occurred at or after this RFC 3339 time",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"occurred_at_to": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Only return events that occurred at or before this RFC 3339 time",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"organization_slug": {
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+			},
+			"subject_types": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Only return events about subjects of these types, such as PIPELINE",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(auditEventEnumFormat, "must be an audit subject type such as PIPELINE"),
+				},
+			},
+
+			"audit_events": schemaAuditEventList(DataSourceFullEntity),
+		},
+	}
+}
+
+// Read the audit events of an organization from the Buildkite API and convert to the Terraform schema.
+func dataSourceAuditEventsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	filter := map[string]interface{}{}
+	if value, ok := d.GetOk("actor_uuid"); ok {
+		filter["actor"] = []string{value.(string)}
+	}
+	if value, ok := d.GetOk("event_types"); ok {
+		filter["type"] = auditEventEnums(value.(*schema.Set))
+	}
+	if value, ok := d.GetOk("occurred_at_from"); ok {
+		filter["occurredAtFrom"] = value.(string)
+	}
+	if value, ok := d.GetOk("occurred_at_to"); ok {
+		filter["occurredAtTo"] = value.(string)
+	}
+	if value, ok := d.GetOk("subject_types"); ok {
+		filter["subjectType"] = auditEventEnums(value.(*schema.Set))
+	}
+
+	events, err := client.readAuditEvents(d.Get("organization_slug").(string), filter, d.Get("limit").(int))
+	if err != nil {
+		return err
+	}
+
+	list := []interface{}{}
+	uuids := []string{}
+	for _, item := range events {
+		list = append(list, item.convert(DataSourceFullEntity))
+		uuids = append(uuids, item.UUID)
+	}
+
+	if err := d.Set("audit_events", list); err != nil {
+		return fmt.Errorf("error setting audit_events: %s", err)
+	}
+
+	d.SetId(dataSourceID(uuids...))
+	return nil
+}
+
+// Convert a set of enum names to GraphQL enum values, in sorted order.
+func auditEventEnums(set *schema.Set) []interface{} {
+	names := []string{}
+	for _, name := range set.List() {
+		names = append(names, name.(string))
+	}
+	sort.Strings(names)
+
+	values := []interface{}{}
+	for _, name := range names {
+		values = append(values, enum(name))
+	}
+	return values
+}
+
+// Construct a Terraform schema definition for a list of audit events.
+func schemaAuditEventList(mode SchemaMode) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of Buildkite audit events",
+		Elem:        schemaAuditEvent(mode),
+	}
+}
+
+// Construct a Terraform schema definition for an audit event.
+func schemaAuditEvent(mode SchemaMode) *schema.Resource {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	case DataSourceFullEntity:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"actor_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"actor_type": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"actor_uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"context": &schema.Schema{
+					Type:        schema.TypeMap,
+					Description: "Where the event came from: its type, request IP address and user agent",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"data": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "JSON payload describing the change",
+					Computed:    true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"occurred_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"subject_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"subject_type": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"subject_uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	default:
+		return &schema.Resource{}
+	}
+}
+
+// AuditEvent defines the properties on the Buildkite API to map to Terraform.
+type AuditEvent struct {
+	Actor struct {
+		Name string
+		Type string
+		UUID string
+	}
+	Context struct {
+		RequestIPAddress string `json:"requestIpAddress"`
+		RequestUserAgent string
+		Typename         string `json:"__typename"`
+	}
+	Data       json.RawMessage
+	ID         string
+	OccurredAt string
+	Subject    struct {
+		Name string
+		Type string
+		UUID string
+	}
+	Type string
+	UUID string
+}
+
+// AuditEventList defines the properties on the Buildkite API to map to Terraform.
+type AuditEventList struct {
+	Count int
+	Edges []struct {
+		Node AuditEvent
+	}
+	PageInfo PageInfo
+}
+
+// Convert a Buildkite API type to a Terraform structure.
+func (source *AuditEvent) convert(mode SchemaMode) map[string]interface{} {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return map[string]interface{}{
+			"uuid": source.UUID,
+		}
+	case DataSourceFullEntity:
+		// The JSON scalar may arrive either as an object or as an encoded string
+		data := ""
+		if err := json.Unmarshal(source.Data, &data); err != nil && string(source.Data) != "null" {
+			data = string(source.Data)
+		}
+		return map[string]interface{}{
+			"actor_name": source.Actor.Name,
+			"actor_type": source.Actor.Type,
+			"actor_uuid": source.Actor.UUID,
+			"context": map[string]interface{}{
+				"request_ip_address": source.Context.RequestIPAddress,
+				"request_user_agent": source.Context.RequestUserAgent,
+				"type":               source.Context.Typename,
+			},
+			"data":         data,
+			"id":           source.ID,
+			"occurred_at":  source.OccurredAt,
+			"subject_name": source.Subject.Name,
+			"subject_type": source.Subject.Type,
+			"subject_uuid": source.Subject.UUID,
+			"type":         source.Type,
+			"uuid":         source.UUID,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// Retrieve the fields of an audit event.
+const fieldsAuditEvent = "actor { name type uuid } " +
+	"context { __typename " +
+	"... on AuditAPIContext { requestIpAddress requestUserAgent } " +
+	"... on AuditWebContext { requestIpAddress requestUserAgent } } " +
+	"data " +
+	"id " +
+	"occurredAt " +
+	"subject { name type uuid } " +
+	"type " +
+	"uuid"
+
+// Retrieve one page of the audit events of an organization, with further
+// connection arguments to filter the events by.
+const queryOrganizationAuditEvents = "organization(slug: %s) { " +
+	"auditEvents(first: %d, after: %s%s) { " +
+	"edges { node { " + fieldsAuditEvent + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Read up to limit audit events of an organization matching the given
+// connection arguments from the Buildkite API.
+func (client *Client) readAuditEvents(slug string, filter map[string]interface{}, limit int) ([]AuditEvent, error) {
+	values := map[string]string{}
+	for key, value := range filter {
+		values[key] = literal(value)
+	}
+	arguments := ""
+	for _, key := range sortedKeys(values) {
+		arguments += ", " + key + ": " + values[key]
+	}
+
+	var events []AuditEvent
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ AuditEvents AuditEventList }
+		first := pageSize
+		if remaining := limit - len(events); remaining < first {
+			first = remaining
+		}
+		err := client.Query(&page, queryOrganizationAuditEvents,
+			quote(client.organization(slug)),
+			first,
+			after,
+			arguments)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.AuditEvents.Edges {
+			events = append(events, edge.Node)
+		}
+		if len(events) >= limit {
+			return PageInfo{}, nil
+		}
+		return page.AuditEvents.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
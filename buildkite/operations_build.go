@@ -2,8 +2,8 @@ package buildkite
 
 import (
 	"fmt"
+	"sort"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -26,16 +26,22 @@ func dataSourceBuildsRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].UUID < builds[j].UUID
+	})
+
 	list := []interface{}{}
+	uuids := []string{}
 	for _, item := range builds {
 		list = append(list, item.convert(DataSourceFullEntity))
+		uuids = append(uuids, item.UUID)
 	}
 
 	if err := d.Set("builds", list); err != nil {
 		return fmt.Errorf("error setting builds: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID(uuids...))
 	return err
 }
 
@@ -77,7 +83,7 @@ func schemaBuild(mode SchemaMode) *schema.Resource {
 
 // Build defines the properties on the Buildkite API to map to Terraform.
 type Build struct {
-	Annotations   []Annotation
+	Annotations   AnnotationList
 	Branch        string
 	CanceledAt    string
 	CanceledBy    User
@@ -105,32 +111,6 @@ type Build struct {
 	UUID string
 }
 
-type Annotation struct {
-	Body struct {
-		HTML string
-		Text string
-	}
-	Context   string
-	CreatedAt string
-	ID        string
-	Style     string
-	UpdatedAt string
-	UUID      string
-}
-
-/*
-type Artifact struct {
-	DownloadURL graphql.String
-	ID          graphql.ID
-	MimeType    graphql.String
-	Path        graphql.String
-	Sha1Sum     graphql.String
-	Size        graphql.Int
-	State       graphql.String
-	UUID        graphql.ID
-}
-*/
-
 // BuildList defines the properties on the Buildkite API to map to Terraform.
 type BuildList struct {
 	Count int
@@ -166,3 +146,64 @@ func (source *BuildList) convert(mode SchemaMode) (result []interface{}) {
 func (client *Client) readBuilds() ([]Build, error) {
 	return nil, nil
 }
+
+// Retrieve the fields of a build.
+const fieldsBuild = "branch " +
+	"canceledAt " +
+	"commit " +
+	"createdAt " +
+	"finishedAt " +
+	"id " +
+	"message " +
+	"number " +
+	"pipeline { id slug } " +
+	"scheduledAt " +
+	"startedAt " +
+	"state " +
+	"url " +
+	"uuid"
+
+// Retrieve a build by its GraphQL identifier.
+const queryNodeBuild = "node(id: %s) { ... on Build { " + fieldsBuild + " } }"
+
+// Retrieve a build by its "organization/pipeline/number" slug.
+const queryBuild = "build(slug: %s) { " + fieldsBuild + " }"
+
+// Read a single build from the Buildkite API by its GraphQL identifier.
+func (client *Client) readBuild(id string) (*Build, error) {
+	var build Build
+	if err := client.Query(&build, queryNodeBuild, quote(id)); err != nil {
+		return nil, err
+	}
+	if build.ID == "" {
+		return nil, errNotFound
+	}
+	return &build, nil
+}
+
+// Read a single build from the Buildkite API by its organization and pipeline slugs and number.
+func (client *Client) readBuildBySlug(organization string, pipeline string, number string) (*Build, error) {
+	var build Build
+	err := client.Query(&build, queryBuild,
+		quote(client.organization(organization)+"/"+pipeline+"/"+number))
+	if err != nil {
+		return nil, err
+	}
+	if build.ID == "" {
+		return nil, errNotFound
+	}
+	return &build, nil
+}
+
+// Create a build through the Buildkite REST API, which unlike GraphQL
+// accepts meta-data, returning the GraphQL identifier of the new build.
+func (client *Client) createBuild(organization string, pipeline string, body map[string]interface{}) (string, error) {
+	var build struct {
+		GraphQLID string `json:"graphql_id"`
+	}
+	url := pipelineURL(client.organization(organization), pipeline) + "/builds"
+	if _, err := client.rest("POST", url, body, &build); err != nil {
+		return "", err
+	}
+	return build.GraphQLID, nil
+}
@@ -0,0 +1,305 @@
+package buildkite
+
+// Cluster defines the properties on the Buildkite API to map to Terraform.
+type Cluster struct {
+	Color        string
+	CreatedAt    string
+	DefaultQueue struct{ ID string }
+	Description  string
+	Emoji        string
+	ID           string
+	Name         string
+	UUID         string
+}
+
+// ClusterQueue defines the properties on the Buildkite API to map to Terraform.
+type ClusterQueue struct {
+	Cluster     Cluster
+	Description string
+	ID          string
+	Key         string
+	UUID        string
+}
+
+// ClusterQueueList defines the properties on the Buildkite API to map to Terraform.
+type ClusterQueueList struct {
+	Count int
+	Edges []struct {
+		Node ClusterQueue
+	}
+	PageInfo PageInfo
+}
+
+// ClusterAgentToken defines the properties on the Buildkite API to map to Terraform.
+type ClusterAgentToken struct {
+	Cluster     Cluster
+	CreatedAt   string
+	Description string
+	ID          string
+	UUID        string
+}
+
+// ClusterAgentTokenList defines the properties on the Buildkite API to map to Terraform.
+type ClusterAgentTokenList struct {
+	Count int
+	Edges []struct {
+		Node ClusterAgentToken
+	}
+	PageInfo PageInfo
+}
+
+// Retrieve the fields of a cluster.
+const fieldsCluster = "color " +
+	"createdAt " +
+	"defaultQueue { id } " +
+	"description " +
+	"emoji " +
+	"id " +
+	"name " +
+	"uuid"
+
+// Retrieve a cluster by its GraphQL identifier.
+const queryNodeCluster = "node(id: %s) { ... on Cluster { " + fieldsCluster + " } }"
+
+// Retrieve a cluster by its organization slug and UUID.
+const queryOrganizationCluster = "organization(slug: %s) { cluster(id: %s) { " + fieldsCluster + " } }"
+
+// Create a cluster from a GraphQL input object.
+const mutationClusterCreate = "clusterCreate(input: %s) { cluster { " + fieldsCluster + " } }"
+
+// Update a cluster from a GraphQL input object.
+const mutationClusterUpdate = "clusterUpdate(input: %s) { cluster { " + fieldsCluster + " } }"
+
+// Delete a cluster by its organization and cluster GraphQL identifiers.
+const mutationClusterDelete = "clusterDelete(input: { organizationId: %s, id: %s }) { clientMutationId }"
+
+// Read a single cluster from the Buildkite API by its GraphQL identifier.
+func (client *Client) readCluster(id string) (*Cluster, error) {
+	var cluster Cluster
+	if err := client.Query(&cluster, queryNodeCluster, quote(id)); err != nil {
+		return nil, err
+	}
+	if cluster.ID == "" {
+		return nil, errNotFound
+	}
+	return &cluster, nil
+}
+
+// Read a single cluster from the Buildkite API by its organization slug and UUID.
+func (client *Client) readClusterByUUID(organization string, uuid string) (*Cluster, error) {
+	var page struct{ Cluster Cluster }
+	err := client.Query(&page, queryOrganizationCluster, quote(client.organization(organization)), quote(uuid))
+	if err != nil {
+		return nil, err
+	}
+	if page.Cluster.ID == "" {
+		return nil, errNotFound
+	}
+	return &page.Cluster, nil
+}
+
+// Create a cluster through the Buildkite API.
+func (client *Client) createCluster(input map[string]interface{}) (*Cluster, error) {
+	var payload struct{ Cluster Cluster }
+	if err := client.Mutate(&payload, mutationClusterCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.Cluster, nil
+}
+
+// Update a cluster through the Buildkite API.
+func (client *Client) updateCluster(input map[string]interface{}) (*Cluster, error) {
+	var payload struct{ Cluster Cluster }
+	if err := client.Mutate(&payload, mutationClusterUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.Cluster, nil
+}
+
+// Delete a cluster through the Buildkite API.
+func (client *Client) deleteCluster(organizationID string, id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationClusterDelete, quote(organizationID), quote(id))
+}
+
+// Retrieve the fields of a cluster queue.
+const fieldsClusterQueue = "cluster { id uuid } " +
+	"description " +
+	"id " +
+	"key " +
+	"uuid"
+
+// Retrieve a cluster queue by its GraphQL identifier.
+const queryNodeClusterQueue = "node(id: %s) { ... on ClusterQueue { " + fieldsClusterQueue + " } }"
+
+// Retrieve one page of queues for a cluster by its organization slug and UUID.
+const queryClusterQueues = "organization(slug: %s) { cluster(id: %s) { " +
+	"queues(first: %d, after: %s) { " +
+	"edges { node { " + fieldsClusterQueue + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Create a cluster queue from a GraphQL input object.
+const mutationClusterQueueCreate = "clusterQueueCreate(input: %s) { clusterQueue { " + fieldsClusterQueue + " } }"
+
+// Update a cluster queue from a GraphQL input object.
+const mutationClusterQueueUpdate = "clusterQueueUpdate(input: %s) { clusterQueue { " + fieldsClusterQueue + " } }"
+
+// Delete a cluster queue by its organization and queue GraphQL identifiers.
+const mutationClusterQueueDelete = "clusterQueueDelete(input: { organizationId: %s, id: %s }) { clientMutationId }"
+
+// Read a single cluster queue from the Buildkite API by its GraphQL identifier.
+func (client *Client) readClusterQueue(id string) (*ClusterQueue, error) {
+	var queue ClusterQueue
+	if err := client.Query(&queue, queryNodeClusterQueue, quote(id)); err != nil {
+		return nil, err
+	}
+	if queue.ID == "" {
+		return nil, errNotFound
+	}
+	return &queue, nil
+}
+
+// Read all queues of a cluster from the Buildkite API by its organization slug and UUID.
+func (client *Client) readClusterQueues(organization string, uuid string) ([]ClusterQueue, error) {
+	var queues []ClusterQueue
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct {
+			Cluster struct{ Queues ClusterQueueList }
+		}
+		err := client.Query(&page, queryClusterQueues,
+			quote(client.organization(organization)),
+			quote(uuid),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Cluster.Queues.Edges {
+			queues = append(queues, edge.Node)
+		}
+		return page.Cluster.Queues.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return queues, nil
+}
+
+// Create a cluster queue through the Buildkite API.
+func (client *Client) createClusterQueue(input map[string]interface{}) (*ClusterQueue, error) {
+	var payload struct{ ClusterQueue ClusterQueue }
+	if err := client.Mutate(&payload, mutationClusterQueueCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.ClusterQueue, nil
+}
+
+// Update a cluster queue through the Buildkite API.
+func (client *Client) updateClusterQueue(input map[string]interface{}) (*ClusterQueue, error) {
+	var payload struct{ ClusterQueue ClusterQueue }
+	if err := client.Mutate(&payload, mutationClusterQueueUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.ClusterQueue, nil
+}
+
+// Delete a cluster queue through the Buildkite API.
+func (client *Client) deleteClusterQueue(organizationID string, id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationClusterQueueDelete, quote(organizationID), quote(id))
+}
+
+// Retrieve the fields of a cluster agent token.
+const fieldsClusterAgentToken = "cluster { id uuid } " +
+	"createdAt " +
+	"description " +
+	"id " +
+	"uuid"
+
+// Retrieve a cluster agent token by its GraphQL identifier.
+const queryNodeClusterAgentToken = "node(id: %s) { ... on ClusterToken { " + fieldsClusterAgentToken + " } }"
+
+// Retrieve one page of agent tokens for a cluster by its organization slug and UUID.
+const queryClusterAgentTokens = "organization(slug: %s) { cluster(id: %s) { " +
+	"agentTokens(first: %d, after: %s) { " +
+	"edges { node { " + fieldsClusterAgentToken + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Create a cluster agent token from a GraphQL input object.
+const mutationClusterAgentTokenCreate = "clusterAgentTokenCreate(input: %s) { " +
+	"clusterAgentTokenEdge { node { " + fieldsClusterAgentToken + " } } tokenValue }"
+
+// Update a cluster agent token from a GraphQL input object.
+const mutationClusterAgentTokenUpdate = "clusterAgentTokenUpdate(input: %s) { " +
+	"clusterAgentToken { " + fieldsClusterAgentToken + " } }"
+
+// Revoke a cluster agent token by its organization and token GraphQL identifiers.
+const mutationClusterAgentTokenRevoke = "clusterAgentTokenRevoke(input: { organizationId: %s, id: %s }) { clientMutationId }"
+
+// Read a single cluster agent token from the Buildkite API by its GraphQL identifier.
+func (client *Client) readClusterAgentToken(id string) (*ClusterAgentToken, error) {
+	var token ClusterAgentToken
+	if err := client.Query(&token, queryNodeClusterAgentToken, quote(id)); err != nil {
+		return nil, err
+	}
+	if token.ID == "" {
+		return nil, errNotFound
+	}
+	return &token, nil
+}
+
+// Read all agent tokens of a cluster from the Buildkite API by its organization slug and UUID.
+func (client *Client) readClusterAgentTokens(organization string, uuid string) ([]ClusterAgentToken, error) {
+	var tokens []ClusterAgentToken
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct {
+			Cluster struct{ AgentTokens ClusterAgentTokenList }
+		}
+		err := client.Query(&page, queryClusterAgentTokens,
+			quote(client.organization(organization)),
+			quote(uuid),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Cluster.AgentTokens.Edges {
+			tokens = append(tokens, edge.Node)
+		}
+		return page.Cluster.AgentTokens.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Create a cluster agent token through the Buildkite API, returning the token
+// value that is only available at creation.
+func (client *Client) createClusterAgentToken(input map[string]interface{}) (*ClusterAgentToken, string, error) {
+	var payload struct {
+		ClusterAgentTokenEdge struct{ Node ClusterAgentToken }
+		TokenValue            string
+	}
+	if err := client.Mutate(&payload, mutationClusterAgentTokenCreate, literal(input)); err != nil {
+		return nil, "", err
+	}
+	return &payload.ClusterAgentTokenEdge.Node, payload.TokenValue, nil
+}
+
+// Update a cluster agent token through the Buildkite API.
+func (client *Client) updateClusterAgentToken(input map[string]interface{}) (*ClusterAgentToken, error) {
+	var payload struct{ ClusterAgentToken ClusterAgentToken }
+	if err := client.Mutate(&payload, mutationClusterAgentTokenUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.ClusterAgentToken, nil
+}
+
+// Revoke a cluster agent token through the Buildkite API.
+func (client *Client) revokeClusterAgentToken(organizationID string, id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationClusterAgentTokenRevoke, quote(organizationID), quote(id))
+}
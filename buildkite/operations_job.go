@@ -0,0 +1,260 @@
+package buildkite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Define a Terraform data source for the jobs of a build.
+func dataSourceBuildJobs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildJobsRead,
+
+		Schema: map[string]*schema.Schema{
+			"build_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the build to list the jobs of",
+				Required:    true,
+			},
+
+			"jobs": schemaJobList(DataSourceFullEntity),
+		},
+	}
+}
+
+// Read the jobs of a build from the Buildkite API and convert to the Terraform schema.
+func dataSourceBuildJobsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	jobs, err := client.readBuildJobs(d.Get("build_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// Jobs stay in pipeline order, which is meaningful for finding the step that failed
+	list := []interface{}{}
+	uuids := []string{}
+	for _, item := range jobs {
+		list = append(list, item.convert(DataSourceFullEntity))
+		uuids = append(uuids, item.UUID)
+	}
+
+	if err := d.Set("jobs", list); err != nil {
+		return fmt.Errorf("error setting jobs: %s", err)
+	}
+
+	d.SetId(dataSourceID(uuids...))
+	return nil
+}
+
+// Construct a Terraform schema definition for a list of jobs.
+func schemaJobList(mode SchemaMode) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of Buildkite build jobs",
+		Elem:        schemaJob(mode),
+	}
+}
+
+// Construct a Terraform schema definition for a job.
+func schemaJob(mode SchemaMode) *schema.Resource {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	case DataSourceFullEntity:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"agent": &schema.Schema{
+					Type:        schema.TypeList,
+					Description: "Agent that ran the job, if any",
+					Computed:    true,
+					Elem:        schemaAgent(DataSourceReferenceOnly),
+				},
+				"agent_query_rules": &schema.Schema{
+					Type:        schema.TypeList,
+					Description: "Queue and tag rules used to pick the agent",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"created_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"exit_status": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"finished_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"label": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"retries_count": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"runnable_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"scheduled_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"started_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"state": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"step_key": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Kind of job: BLOCK, COMMAND, TRIGGER or WAIT",
+					Computed:    true,
+				},
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	default:
+		return &schema.Resource{}
+	}
+}
+
+// Job defines the properties on the Buildkite API to map to Terraform; only
+// command jobs have an agent, exit status, retries and most timestamps.
+type Job struct {
+	Agent           *Agent
+	AgentQueryRules []string
+	CreatedAt       string
+	ExitStatus      string
+	FinishedAt      string
+	ID              string
+	Label           string
+	RetriesCount    int
+	RunnableAt      string
+	ScheduledAt     string
+	StartedAt       string
+	State           string
+	Step            struct{ Key string }
+	Typename        string `json:"__typename"`
+	UUID            string
+}
+
+// JobList defines the properties on the Buildkite API to map to Terraform.
+type JobList struct {
+	Count int
+	Edges []struct {
+		Node Job
+	}
+	PageInfo PageInfo
+}
+
+// Convert a Buildkite API type to a Terraform structure.
+func (source *Job) convert(mode SchemaMode) map[string]interface{} {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return map[string]interface{}{
+			"uuid": source.UUID,
+		}
+	case DataSourceFullEntity:
+		agent := []interface{}{}
+		if source.Agent != nil {
+			agent = append(agent, source.Agent.convert(DataSourceReferenceOnly))
+		}
+		return map[string]interface{}{
+			"agent":             agent,
+			"agent_query_rules": source.AgentQueryRules,
+			"created_at":        source.CreatedAt,
+			"exit_status":       source.ExitStatus,
+			"finished_at":       source.FinishedAt,
+			"id":                source.ID,
+			"label":             source.Label,
+			"retries_count":     source.RetriesCount,
+			"runnable_at":       source.RunnableAt,
+			"scheduled_at":      source.ScheduledAt,
+			"started_at":        source.StartedAt,
+			"state":             source.State,
+			"step_key":          source.Step.Key,
+			"type":              strings.ToUpper(strings.TrimPrefix(source.Typename, "JobType")),
+			"uuid":              source.UUID,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// Retrieve the fields shared by every type of job.
+const fieldsJobCommon = "id " +
+	"label " +
+	"state " +
+	"step { key } " +
+	"uuid"
+
+// Retrieve the fields of a job of any type.
+const fieldsJob = "__typename " +
+	"... on JobTypeBlock { " + fieldsJobCommon + " } " +
+	"... on JobTypeCommand { " + fieldsJobCommon + " " +
+	"agent { uuid } " +
+	"agentQueryRules " +
+	"createdAt " +
+	"exitStatus " +
+	"finishedAt " +
+	"retriesCount " +
+	"runnableAt " +
+	"scheduledAt " +
+	"startedAt } " +
+	"... on JobTypeTrigger { " + fieldsJobCommon + " } " +
+	"... on JobTypeWait { " + fieldsJobCommon + " }"
+
+// Retrieve one page of the jobs of a build by its GraphQL identifier.
+const queryNodeBuildJobs = "node(id: %s) { ... on Build { " +
+	"jobs(first: %d, after: %s) { " +
+	"edges { node { " + fieldsJob + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Read the jobs of a build from the Buildkite API in pipeline order.
+func (client *Client) readBuildJobs(id string) ([]Job, error) {
+	var jobs []Job
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Jobs JobList }
+		err := client.Query(&page, queryNodeBuildJobs, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Jobs.Edges {
+			jobs = append(jobs, edge.Node)
+		}
+		return page.Jobs.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
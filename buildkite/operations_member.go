@@ -2,8 +2,9 @@ package buildkite
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -13,6 +14,12 @@ func dataSourceMembers() *schema.Resource {
 		Read: dataSourceMembersRead,
 
 		Schema: map[string]*schema.Schema{
+			"organization_slug": {
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+			},
+
 			"members": schemaMemberList(DataSourceFullEntity),
 		},
 	}
@@ -21,7 +28,10 @@ func dataSourceMembers() *schema.Resource {
 // Read members from the Buildkite API and convert to the Terraform schema.
 func dataSourceMembersRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	members, err := client.readMembers()
+
+	slug := d.Get("organization_slug").(string)
+
+	members, err := client.readMembers(slug)
 	if err != nil {
 		return err
 	}
@@ -35,10 +45,47 @@ func dataSourceMembersRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting members: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID("members", client.organization(slug)))
 	return err
 }
 
+// Define a Terraform data source for a single member.
+func dataSourceMember() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMemberRead,
+
+		Schema: schemaDataSourceLookup(schemaMember(DataSourceFullEntity), "email", "uuid"),
+	}
+}
+
+// Look up a single member from the Buildkite API and convert to the Terraform schema.
+func dataSourceMemberRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := d.Get("organization_slug").(string)
+
+	members, err := client.readMembers(slug)
+	if err != nil {
+		return err
+	}
+
+	list := []map[string]interface{}{}
+	for _, item := range members {
+		list = append(list, item.convert(DataSourceFullEntity))
+	}
+
+	member, err := findEntity(d, "member", list, "email", "uuid")
+	if err != nil {
+		return err
+	}
+	if err := setResourceData(d, member); err != nil {
+		return err
+	}
+
+	d.SetId(member["id"].(string))
+	return nil
+}
+
 // Construct a Terraform schema definition for a list of members.
 func schemaMemberList(mode SchemaMode) *schema.Schema {
 	return &schema.Schema{
@@ -64,6 +111,94 @@ func schemaMember(mode SchemaMode) *schema.Resource {
 	case DataSourceFullEntity:
 		return &schema.Resource{
 			Schema: map[string]*schema.Schema{
+				"created_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"email": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"role": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"user_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"user_uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	default:
+		return &schema.Resource{}
+	}
+}
+
+// Construct a Terraform schema definition for an optional user reference.
+func schemaUserList(mode SchemaMode) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Buildkite user, if any",
+		Elem:        schemaUser(mode),
+	}
+}
+
+// Construct a Terraform schema definition for a user.
+func schemaUser(mode SchemaMode) *schema.Resource {
+	switch mode {
+	case DataSourceReferenceOnly:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}
+	case DataSourceFullEntity:
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"avatar_url": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"bot": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"email": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"has_password": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
 				"uuid": &schema.Schema{
 					Type:     schema.TypeString,
 					Computed: true,
@@ -77,9 +212,14 @@ func schemaMember(mode SchemaMode) *schema.Resource {
 
 // Member defines the properties on the Buildkite API to map to Terraform.
 type Member struct {
-	UUID string
+	CreatedAt string
+	ID        string
+	Role      string
+	User      User
+	UUID      string
 }
 
+// User defines the properties on the Buildkite API to map to Terraform.
 type User struct {
 	Avatar      struct{ URL string }
 	Bot         bool
@@ -96,6 +236,7 @@ type MemberList struct {
 	Edges []struct {
 		Node Member
 	}
+	PageInfo PageInfo
 }
 
 // Convert a Buildkite API type to a Terraform structure.
@@ -106,14 +247,51 @@ func (source *Member) convert(mode SchemaMode) map[string]interface{} {
 			"uuid": source.UUID,
 		}
 	case DataSourceFullEntity:
+		return map[string]interface{}{
+			"created_at": source.CreatedAt,
+			"email":      source.User.Email,
+			"id":         source.ID,
+			"name":       source.User.Name,
+			"role":       source.Role,
+			"user_id":    source.User.ID,
+			"user_uuid":  source.User.UUID,
+			"uuid":       source.UUID,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// Convert a Buildkite API type to a Terraform structure.
+func (source *User) convert(mode SchemaMode) map[string]interface{} {
+	switch mode {
+	case DataSourceReferenceOnly:
 		return map[string]interface{}{
 			"uuid": source.UUID,
 		}
+	case DataSourceFullEntity:
+		return map[string]interface{}{
+			"avatar_url":   source.Avatar.URL,
+			"bot":          source.Bot,
+			"email":        source.Email,
+			"has_password": source.HasPassword,
+			"id":           source.ID,
+			"name":         source.Name,
+			"uuid":         source.UUID,
+		}
 	default:
 		return map[string]interface{}{}
 	}
 }
 
+// Convert an optional Buildkite API user to a Terraform list of at most one element.
+func (source *User) convertList(mode SchemaMode) []interface{} {
+	if source.UUID == "" {
+		return []interface{}{}
+	}
+	return []interface{}{source.convert(mode)}
+}
+
 // Convert a Buildkite API type to a Terraform structure.
 func (source *MemberList) convert(mode SchemaMode) (result []interface{}) {
 	for _, ref := range source.Edges {
@@ -122,6 +300,97 @@ func (source *MemberList) convert(mode SchemaMode) (result []interface{}) {
 	return
 }
 
-func (client *Client) readMembers() ([]Member, error) {
-	return nil, nil
+// Retrieve the fields of a user.
+const fieldsUser = "avatar { url } bot email hasPassword id name uuid"
+
+// Retrieve the fields of an organization member.
+const fieldsMember = "createdAt " +
+	"id " +
+	"role " +
+	"user { " + fieldsUser + " } " +
+	"uuid"
+
+// Retrieve one page of members for an organization.
+const queryOrganizationMembers = "organization(slug: %s) { " +
+	"members(first: %d, after: %s) { " +
+	"edges { node { " + fieldsMember + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Read a list of all members for an organization from the Buildkite API.
+func (client *Client) readMembers(slug string) ([]Member, error) {
+	var members []Member
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Members MemberList }
+		err := client.Query(&page, queryOrganizationMembers,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Members.Edges {
+			members = append(members, edge.Node)
+		}
+		return page.Members.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UUID < members[j].UUID
+	})
+	return members, nil
+}
+
+// Retrieve an organization member by its GraphQL identifier.
+const queryNodeMember = "node(id: %s) { ... on OrganizationMember { " + fieldsMember + " } }"
+
+// Update the role of an organization member.
+const mutationMemberUpdate = "organizationMemberUpdate(input: { id: %s, role: %s }) { " +
+	"organizationMember { " + fieldsMember + " } }"
+
+// Remove a member from an organization by its GraphQL identifier.
+const mutationMemberDelete = "organizationMemberDelete(input: { id: %s }) { clientMutationId }"
+
+// Read a single organization member from the Buildkite API by its GraphQL identifier.
+func (client *Client) readMember(id string) (*Member, error) {
+	var member Member
+	if err := client.Query(&member, queryNodeMember, quote(id)); err != nil {
+		return nil, err
+	}
+	if member.ID == "" {
+		return nil, errNotFound
+	}
+	return &member, nil
+}
+
+// Read a single organization member from the Buildkite API by the email of the user.
+func (client *Client) readMemberByEmail(organization string, email string) (*Member, error) {
+	members, err := client.readMembers(organization)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.User.Email, email) {
+			return &member, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+// Update the role of an organization member through the Buildkite API.
+func (client *Client) updateMember(id string, role string) (*Member, error) {
+	var payload struct{ OrganizationMember Member }
+	if err := client.Mutate(&payload, mutationMemberUpdate, quote(id), role); err != nil {
+		return nil, err
+	}
+	return &payload.OrganizationMember, nil
+}
+
+// Remove a member from an organization through the Buildkite API.
+func (client *Client) deleteMember(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationMemberDelete, quote(id))
 }
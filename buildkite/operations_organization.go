@@ -1,11 +1,9 @@
 package buildkite
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"sort"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/peterhellberg/link"
 )
@@ -30,15 +28,17 @@ func dataSourceOrganizationsRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	list := []interface{}{}
+	slugs := []string{}
 	for _, item := range organizations {
 		list = append(list, item.convert(DataSourceFullEntity))
+		slugs = append(slugs, item.Slug)
 	}
 
 	if err := d.Set("organizations", list); err != nil {
 		return fmt.Errorf("error setting organizations: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID(slugs...))
 	return err
 }
 
@@ -183,19 +183,11 @@ func (client *Client) readOrganizations() ([]Organization, error) {
 	var organizations []Organization
 	url := "https://api.buildkite.com/v2/organizations"
 	for {
-		res, err := client.httpAPI.Get(url)
-		if err != nil {
-			return nil, err
-		}
-
 		var temp []Organization
-		bodyBytes, err := ioutil.ReadAll(res.Body)
+		res, err := client.rest("GET", url, nil, &temp)
 		if err != nil {
 			return nil, err
 		}
-		if err := json.Unmarshal(bodyBytes, &temp); err != nil {
-			return nil, err
-		}
 
 		organizations = append(organizations, temp...)
 
@@ -207,15 +199,19 @@ func (client *Client) readOrganizations() ([]Organization, error) {
 		url = link.URI
 	}
 
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].Slug < organizations[j].Slug
+	})
+
 	// Now enrich organizations list via GraphQL
 	for ix := range organizations {
 		var org *Organization = &organizations[ix]
-		err := client.Query(org, queryOrganizationCount, org.Slug)
+		err := client.Query(org, queryOrganizationCount, quote(org.Slug))
 		if err != nil {
 			return nil, err
 		}
 		err = client.Query(org, queryOrganizationLists,
-			org.Slug,
+			quote(org.Slug),
 			org.Agents.Count,
 			org.Members.Count,
 			org.Pipelines.Count,
@@ -224,6 +220,187 @@ func (client *Client) readOrganizations() ([]Organization, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// Sort the reference lists too, so their order does not follow the API
+		sort.Slice(org.Agents.Edges, func(i, j int) bool {
+			return org.Agents.Edges[i].Node.UUID < org.Agents.Edges[j].Node.UUID
+		})
+		sort.Slice(org.Members.Edges, func(i, j int) bool {
+			return org.Members.Edges[i].Node.UUID < org.Members.Edges[j].Node.UUID
+		})
+		sort.Slice(org.Pipelines.Edges, func(i, j int) bool {
+			return org.Pipelines.Edges[i].Node.Slug < org.Pipelines.Edges[j].Node.Slug
+		})
+		sort.Slice(org.SsoProviders.Edges, func(i, j int) bool {
+			return org.SsoProviders.Edges[i].Node.UUID < org.SsoProviders.Edges[j].Node.UUID
+		})
+		sort.Slice(org.Teams.Edges, func(i, j int) bool {
+			return org.Teams.Edges[i].Node.UUID < org.Teams.Edges[j].Node.UUID
+		})
 	}
 	return organizations, nil
 }
+
+// Retrieve the GraphQL identifier of an organization.
+const queryOrganizationID = "organization(slug: %s) { id }"
+
+// Read the GraphQL identifier of an organization from the Buildkite API.
+func (client *Client) readOrganizationID(slug string) (string, error) {
+	var organization Organization
+	err := client.Query(&organization, queryOrganizationID, quote(client.organization(slug)))
+	if err != nil {
+		return "", err
+	}
+	return organization.ID, nil
+}
+
+// OrganizationInvitation defines the properties on the Buildkite API to map to Terraform.
+type OrganizationInvitation struct {
+	AcceptedAt string
+	AcceptedBy User
+	CreatedAt  string
+	Email      string
+	ExpiresAt  string
+	ID         string
+	Role       string
+	State      string
+	UUID       string
+}
+
+// OrganizationInvitationList defines the properties on the Buildkite API to map to Terraform.
+type OrganizationInvitationList struct {
+	Count int
+	Edges []struct {
+		Node OrganizationInvitation
+	}
+	PageInfo PageInfo
+}
+
+// OrganizationInvitationTeam defines the properties on the Buildkite API to map to Terraform.
+type OrganizationInvitationTeam struct {
+	Role string
+	Team Team
+}
+
+// OrganizationInvitationTeamList defines the properties on the Buildkite API to map to Terraform.
+type OrganizationInvitationTeamList struct {
+	Count int
+	Edges []struct {
+		Node OrganizationInvitationTeam
+	}
+	PageInfo PageInfo
+}
+
+// Retrieve the fields of an organization invitation.
+const fieldsOrganizationInvitation = "acceptedAt " +
+	"acceptedBy { " + fieldsUser + " } " +
+	"createdAt " +
+	"email " +
+	"expiresAt " +
+	"id " +
+	"role " +
+	"state " +
+	"uuid"
+
+// Retrieve an organization invitation by its GraphQL identifier.
+const queryNodeOrganizationInvitation = "node(id: %s) { ... on OrganizationInvitation { " +
+	fieldsOrganizationInvitation + " } }"
+
+// Retrieve one page of the teams of an organization invitation by its GraphQL identifier.
+const queryNodeOrganizationInvitationTeams = "node(id: %s) { ... on OrganizationInvitation { " +
+	"teams(first: %d, after: %s) { " +
+	"edges { node { role team { id } } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Retrieve one page of invitations for an organization.
+const queryOrganizationInvitations = "organization(slug: %s) { " +
+	"invitations(first: %d, after: %s) { " +
+	"edges { node { " + fieldsOrganizationInvitation + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Invite users to an organization from a GraphQL input object.
+const mutationOrganizationInvitationCreate = "organizationInvitationCreate(input: %s) { " +
+	"invitationEdges { node { " + fieldsOrganizationInvitation + " } } }"
+
+// Revoke an organization invitation by its GraphQL identifier.
+const mutationOrganizationInvitationRevoke = "organizationInvitationRevoke(input: { id: %s }) { " +
+	"organizationInvitation { " + fieldsOrganizationInvitation + " } }"
+
+// Read a single organization invitation from the Buildkite API by its GraphQL identifier.
+func (client *Client) readOrganizationInvitation(id string) (*OrganizationInvitation, error) {
+	var invitation OrganizationInvitation
+	if err := client.Query(&invitation, queryNodeOrganizationInvitation, quote(id)); err != nil {
+		return nil, err
+	}
+	if invitation.ID == "" {
+		return nil, errNotFound
+	}
+	return &invitation, nil
+}
+
+// Read a list of all invitations for an organization from the Buildkite API.
+func (client *Client) readOrganizationInvitations(slug string) ([]OrganizationInvitation, error) {
+	var invitations []OrganizationInvitation
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Invitations OrganizationInvitationList }
+		err := client.Query(&page, queryOrganizationInvitations,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Invitations.Edges {
+			invitations = append(invitations, edge.Node)
+		}
+		return page.Invitations.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Read all teams of an organization invitation from the Buildkite API by its GraphQL identifier.
+func (client *Client) readOrganizationInvitationTeams(id string) ([]OrganizationInvitationTeam, error) {
+	var teams []OrganizationInvitationTeam
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct {
+			Teams OrganizationInvitationTeamList
+		}
+		err := client.Query(&page, queryNodeOrganizationInvitationTeams, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Teams.Edges {
+			teams = append(teams, edge.Node)
+		}
+		return page.Teams.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// Send an organization invitation through the Buildkite API.
+func (client *Client) createOrganizationInvitation(input map[string]interface{}) (*OrganizationInvitation, error) {
+	var payload struct {
+		InvitationEdges []struct{ Node OrganizationInvitation }
+	}
+	if err := client.Mutate(&payload, mutationOrganizationInvitationCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	if len(payload.InvitationEdges) != 1 {
+		return nil, fmt.Errorf("expected one organization invitation, got %d", len(payload.InvitationEdges))
+	}
+	return &payload.InvitationEdges[0].Node, nil
+}
+
+// Revoke an organization invitation through the Buildkite API.
+func (client *Client) revokeOrganizationInvitation(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationOrganizationInvitationRevoke, quote(id))
+}
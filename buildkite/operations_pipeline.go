@@ -2,8 +2,9 @@ package buildkite
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -13,6 +14,12 @@ func dataSourcePipelines() *schema.Resource {
 		Read: dataSourcePipelinesRead,
 
 		Schema: map[string]*schema.Schema{
+			"organization_slug": {
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+			},
+
 			"pipelines": schemaPipelineList(DataSourceFullEntity),
 		},
 	}
@@ -21,7 +28,10 @@ func dataSourcePipelines() *schema.Resource {
 // Read pipelines from the Buildkite API and convert to the Terraform schema.
 func dataSourcePipelinesRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	pipelines, err := client.readPipelines()
+
+	slug := d.Get("organization_slug").(string)
+
+	pipelines, err := client.readPipelines(slug)
 	if err != nil {
 		return err
 	}
@@ -35,10 +45,60 @@ func dataSourcePipelinesRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting pipelines: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID("pipelines", client.organization(slug)))
 	return err
 }
 
+// Define a Terraform data source for a single pipeline.
+func dataSourcePipeline() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePipelineRead,
+
+		Schema: schemaDataSourceLookup(schemaPipeline(DataSourceFullEntity), "slug", "uuid"),
+	}
+}
+
+// Look up a single pipeline from the Buildkite API and convert to the Terraform schema.
+func dataSourcePipelineRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := d.Get("organization_slug").(string)
+
+	var pipeline map[string]interface{}
+	if value, ok := d.GetOk("slug"); ok {
+		item, err := client.readPipelineBySlug(slug, value.(string))
+		if isNotFound(err) {
+			return fmt.Errorf("no pipeline found with slug %q", value)
+		}
+		if err != nil {
+			return err
+		}
+		pipeline = item.convert(DataSourceFullEntity)
+	} else {
+		// Pipelines can only be queried directly by slug, so search for the UUID
+		pipelines, err := client.readPipelines(slug)
+		if err != nil {
+			return err
+		}
+
+		list := []map[string]interface{}{}
+		for _, item := range pipelines {
+			list = append(list, item.convert(DataSourceFullEntity))
+		}
+
+		pipeline, err = findEntity(d, "pipeline", list, "slug", "uuid")
+		if err != nil {
+			return err
+		}
+	}
+	if err := setResourceData(d, pipeline); err != nil {
+		return err
+	}
+
+	d.SetId(pipeline["id"].(string))
+	return nil
+}
+
 // Construct a Terraform schema definition for a list of pipelines.
 func schemaPipelineList(mode SchemaMode) *schema.Schema {
 	return &schema.Schema{
@@ -64,10 +124,102 @@ func schemaPipeline(mode SchemaMode) *schema.Resource {
 	case DataSourceFullEntity:
 		return &schema.Resource{
 			Schema: map[string]*schema.Schema{
+				"archived": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"cancel_intermediate_builds": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"cancel_intermediate_builds_branch_filter": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"cluster_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"commit_short_length": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"created_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"default_branch": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"favorite": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"next_build_number": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"pipeline_template_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"repository": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"repository_provider": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"repository_provider_webhook_url": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"skip_intermediate_builds": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"skip_intermediate_builds_branch_filter": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
 				"slug": &schema.Schema{
 					Type:     schema.TypeString,
 					Computed: true,
 				},
+				"steps": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"url": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"uuid": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"visibility": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"webhook_url": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
 			},
 		}
 	default:
@@ -77,8 +229,10 @@ func schemaPipeline(mode SchemaMode) *schema.Resource {
 
 // Pipeline defines the properties on the Buildkite API to map to Terraform.
 type Pipeline struct {
+	Archived                             bool
 	CancelIntermediateBuilds             bool
 	CancelIntermediateBuildsBranchFilter string
+	Cluster                              Cluster
 	CommitShortLength                    int
 	CreatedAt                            string
 	DefaultBranch                        string
@@ -87,6 +241,7 @@ type Pipeline struct {
 	ID                                   string
 	Name                                 string
 	NextBuildNumber                      int
+	PipelineTemplate                     struct{ ID string }
 	Repository                           struct {
 		Provider struct {
 			Name       string
@@ -106,6 +261,7 @@ type Pipeline struct {
 	WebhookURL                         string
 }
 
+// PipelineSchedule defines the properties on the Buildkite API to map to Terraform.
 type PipelineSchedule struct {
 	Branch        string
 	Commit        string
@@ -131,6 +287,7 @@ type PipelineList struct {
 	Edges []struct {
 		Node Pipeline
 	}
+	PageInfo PageInfo
 }
 
 // Convert a Buildkite API type to a Terraform structure.
@@ -142,7 +299,30 @@ func (source *Pipeline) convert(mode SchemaMode) map[string]interface{} {
 		}
 	case DataSourceFullEntity:
 		return map[string]interface{}{
-			"slug": source.Slug,
+			"archived":                   source.Archived,
+			"cancel_intermediate_builds": source.CancelIntermediateBuilds,
+			"cancel_intermediate_builds_branch_filter": source.CancelIntermediateBuildsBranchFilter,
+			"cluster_id":                             source.Cluster.ID,
+			"commit_short_length":                    source.CommitShortLength,
+			"created_at":                             source.CreatedAt,
+			"default_branch":                         source.DefaultBranch,
+			"description":                            source.Description,
+			"favorite":                               source.Favorite,
+			"id":                                     source.ID,
+			"name":                                   source.Name,
+			"next_build_number":                      source.NextBuildNumber,
+			"pipeline_template_id":                   source.PipelineTemplate.ID,
+			"repository":                             source.Repository.URL,
+			"repository_provider":                    source.Repository.Provider.Name,
+			"repository_provider_webhook_url":        source.Repository.Provider.WebhookURL,
+			"skip_intermediate_builds":               source.SkipIntermediateBuilds,
+			"skip_intermediate_builds_branch_filter": source.SkipIntermediateBuildsBranchFilter,
+			"slug":                                   source.Slug,
+			"steps":                                  source.Steps.YAML,
+			"url":                                    source.URL,
+			"uuid":                                   source.UUID,
+			"visibility":                             source.Visibility,
+			"webhook_url":                            source.WebhookURL,
 		}
 	default:
 		return map[string]interface{}{}
@@ -157,6 +337,257 @@ func (source *PipelineList) convert(mode SchemaMode) (result []interface{}) {
 	return
 }
 
-func (client *Client) readPipelines() ([]Pipeline, error) {
-	return nil, nil
+// Retrieve the fields of a pipeline.
+const fieldsPipeline = "archived " +
+	"cancelIntermediateBuilds " +
+	"cancelIntermediateBuildsBranchFilter " +
+	"cluster { id } " +
+	"commitShortLength " +
+	"createdAt " +
+	"defaultBranch " +
+	"description " +
+	"favorite " +
+	"id " +
+	"name " +
+	"nextBuildNumber " +
+	"pipelineTemplate { id } " +
+	"repository { provider { name url webhookUrl } url } " +
+	"skipIntermediateBuilds " +
+	"skipIntermediateBuildsBranchFilter " +
+	"slug " +
+	"steps { yaml } " +
+	"url " +
+	"uuid " +
+	"visibility " +
+	"webhookURL"
+
+// Retrieve one page of pipelines for an organization.
+const queryOrganizationPipelines = "organization(slug: %s) { " +
+	"pipelines(first: %d, after: %s) { " +
+	"edges { node { " + fieldsPipeline + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Read a list of all pipelines for an organization from the Buildkite API.
+func (client *Client) readPipelines(slug string) ([]Pipeline, error) {
+	var pipelines []Pipeline
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Pipelines PipelineList }
+		err := client.Query(&page, queryOrganizationPipelines,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Pipelines.Edges {
+			pipelines = append(pipelines, edge.Node)
+		}
+		return page.Pipelines.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].Slug < pipelines[j].Slug
+	})
+	return pipelines, nil
+}
+
+// Retrieve a pipeline by its GraphQL identifier.
+const queryNodePipeline = "node(id: %s) { ... on Pipeline { " + fieldsPipeline + " } }"
+
+// Retrieve a pipeline by its "organization/pipeline" slug.
+const queryPipeline = "pipeline(slug: %s) { " + fieldsPipeline + " }"
+
+// Create a pipeline from a GraphQL input object.
+const mutationPipelineCreate = "pipelineCreate(input: %s) { pipeline { " + fieldsPipeline + " } }"
+
+// Update a pipeline from a GraphQL input object.
+const mutationPipelineUpdate = "pipelineUpdate(input: %s) { pipeline { " + fieldsPipeline + " } }"
+
+// Delete a pipeline by its GraphQL identifier.
+const mutationPipelineDelete = "pipelineDelete(input: { id: %s }) { clientMutationId }"
+
+// Archive a pipeline by its GraphQL identifier, keeping its build history.
+const mutationPipelineArchive = "pipelineArchive(input: { id: %s }) { pipeline { " + fieldsPipeline + " } }"
+
+// Restore an archived pipeline by its GraphQL identifier.
+const mutationPipelineUnarchive = "pipelineUnarchive(input: { id: %s }) { pipeline { " + fieldsPipeline + " } }"
+
+// Read a single pipeline from the Buildkite API by its GraphQL identifier.
+func (client *Client) readPipeline(id string) (*Pipeline, error) {
+	var pipeline Pipeline
+	if err := client.Query(&pipeline, queryNodePipeline, quote(id)); err != nil {
+		return nil, err
+	}
+	if pipeline.ID == "" {
+		return nil, errNotFound
+	}
+	return &pipeline, nil
+}
+
+// Read a single pipeline from the Buildkite API by its organization and pipeline slugs.
+func (client *Client) readPipelineBySlug(organization string, slug string) (*Pipeline, error) {
+	var pipeline Pipeline
+	err := client.Query(&pipeline, queryPipeline, quote(client.organization(organization)+"/"+slug))
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// Create a pipeline through the Buildkite API.
+func (client *Client) createPipeline(input map[string]interface{}) (*Pipeline, error) {
+	var payload struct{ Pipeline Pipeline }
+	if err := client.Mutate(&payload, mutationPipelineCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.Pipeline, nil
+}
+
+// Update a pipeline through the Buildkite API.
+func (client *Client) updatePipeline(input map[string]interface{}) (*Pipeline, error) {
+	var payload struct{ Pipeline Pipeline }
+	if err := client.Mutate(&payload, mutationPipelineUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.Pipeline, nil
+}
+
+// Delete a pipeline through the Buildkite API.
+func (client *Client) deletePipeline(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationPipelineDelete, quote(id))
+}
+
+// Archive a pipeline through the Buildkite API.
+func (client *Client) archivePipeline(id string) (*Pipeline, error) {
+	var payload struct{ Pipeline Pipeline }
+	if err := client.Mutate(&payload, mutationPipelineArchive, quote(id)); err != nil {
+		return nil, err
+	}
+	return &payload.Pipeline, nil
+}
+
+// Restore an archived pipeline through the Buildkite API.
+func (client *Client) unarchivePipeline(id string) (*Pipeline, error) {
+	var payload struct{ Pipeline Pipeline }
+	if err := client.Mutate(&payload, mutationPipelineUnarchive, quote(id)); err != nil {
+		return nil, err
+	}
+	return &payload.Pipeline, nil
+}
+
+// PipelineProvider defines the repository provider of a pipeline on the Buildkite REST API.
+type PipelineProvider struct {
+	ID         string
+	Settings   map[string]interface{}
+	WebhookURL string `json:"webhook_url"`
+}
+
+// Locate a pipeline on the Buildkite REST API by its organization and pipeline slugs.
+func pipelineURL(organization string, slug string) string {
+	return fmt.Sprintf("https://api.buildkite.com/v2/organizations/%s/pipelines/%s",
+		url.PathEscape(organization), url.PathEscape(slug))
+}
+
+// Read the repository provider of a pipeline from the Buildkite REST API.
+func (client *Client) readPipelineProvider(organization string, slug string) (*PipelineProvider, error) {
+	var pipeline struct{ Provider PipelineProvider }
+	if _, err := client.rest("GET", pipelineURL(client.organization(organization), slug), nil, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline.Provider, nil
+}
+
+// Update the repository provider settings of a pipeline through the Buildkite REST API.
+func (client *Client) updatePipelineProvider(organization string, slug string, settings map[string]interface{}) error {
+	body := map[string]interface{}{"provider_settings": settings}
+	_, err := client.rest("PATCH", pipelineURL(client.organization(organization), slug), body, nil)
+	return err
+}
+
+// Retrieve the fields of a pipeline schedule.
+const fieldsPipelineSchedule = "branch " +
+	"commit " +
+	"createdAt " +
+	"createdBy { " + fieldsUser + " } " +
+	"cronline " +
+	"enabled " +
+	"env " +
+	"failedAt " +
+	"failedMessage " +
+	"id " +
+	"label " +
+	"message " +
+	"nextBuildAt " +
+	"pipeline { id slug } " +
+	"uuid"
+
+// Retrieve a pipeline schedule by its GraphQL identifier.
+const queryNodePipelineSchedule = "node(id: %s) { ... on PipelineSchedule { " + fieldsPipelineSchedule + " } }"
+
+// Retrieve a pipeline schedule by its "organization/pipeline/uuid" slug.
+const queryPipelineSchedule = "pipelineSchedule(slug: %s) { " + fieldsPipelineSchedule + " }"
+
+// Create a pipeline schedule from a GraphQL input object.
+const mutationPipelineScheduleCreate = "pipelineScheduleCreate(input: %s) { " +
+	"pipelineScheduleEdge { node { " + fieldsPipelineSchedule + " } } }"
+
+// Update a pipeline schedule from a GraphQL input object.
+const mutationPipelineScheduleUpdate = "pipelineScheduleUpdate(input: %s) { " +
+	"pipelineSchedule { " + fieldsPipelineSchedule + " } }"
+
+// Delete a pipeline schedule by its GraphQL identifier.
+const mutationPipelineScheduleDelete = "pipelineScheduleDelete(input: { id: %s }) { clientMutationId }"
+
+// Read a single pipeline schedule from the Buildkite API by its GraphQL identifier.
+func (client *Client) readPipelineSchedule(id string) (*PipelineSchedule, error) {
+	var schedule PipelineSchedule
+	if err := client.Query(&schedule, queryNodePipelineSchedule, quote(id)); err != nil {
+		return nil, err
+	}
+	if schedule.ID == "" {
+		return nil, errNotFound
+	}
+	return &schedule, nil
+}
+
+// Read a single pipeline schedule from the Buildkite API by its slugs and UUID.
+func (client *Client) readPipelineScheduleBySlug(organization string, pipeline string, uuid string) (*PipelineSchedule, error) {
+	var schedule PipelineSchedule
+	err := client.Query(&schedule, queryPipelineSchedule,
+		quote(client.organization(organization)+"/"+pipeline+"/"+uuid))
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Create a pipeline schedule through the Buildkite API.
+func (client *Client) createPipelineSchedule(input map[string]interface{}) (*PipelineSchedule, error) {
+	var payload struct {
+		PipelineScheduleEdge struct{ Node PipelineSchedule }
+	}
+	if err := client.Mutate(&payload, mutationPipelineScheduleCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.PipelineScheduleEdge.Node, nil
+}
+
+// Update a pipeline schedule through the Buildkite API.
+func (client *Client) updatePipelineSchedule(input map[string]interface{}) (*PipelineSchedule, error) {
+	var payload struct{ PipelineSchedule PipelineSchedule }
+	if err := client.Mutate(&payload, mutationPipelineScheduleUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.PipelineSchedule, nil
+}
+
+// Delete a pipeline schedule through the Buildkite API.
+func (client *Client) deletePipelineSchedule(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationPipelineScheduleDelete, quote(id))
 }
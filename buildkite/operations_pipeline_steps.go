@@ -0,0 +1,621 @@
+package buildkite
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Define a Terraform data source that renders typed step blocks to pipeline YAML.
+func dataSourcePipelineSteps() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePipelineStepsRead,
+
+		Schema: map[string]*schema.Schema{
+			"agents": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Agent targeting rules applied to every step",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"env": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Environment variables applied to every step",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"step": schemaPipelineStepList(false),
+
+			"yaml": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Canonical pipeline YAML for the steps attribute of a pipeline",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Render the step blocks to canonical pipeline YAML.
+func dataSourcePipelineStepsRead(d *schema.ResourceData, m interface{}) error {
+	steps, err := renderPipelineSteps(d.Get("step").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	pipeline := map[string]interface{}{
+		"steps": steps,
+	}
+	if agents := d.Get("agents").(map[string]interface{}); len(agents) > 0 {
+		pipeline["agents"] = agents
+	}
+	if env := d.Get("env").(map[string]interface{}); len(env) > 0 {
+		pipeline["env"] = env
+	}
+
+	rendered, err := yaml.Marshal(pipeline)
+	if err != nil {
+		return err
+	}
+	canonical, err := canonicalPipelineSteps(string(rendered))
+	if err != nil {
+		return err
+	}
+	if problems := validatePipelineStepsYAML(canonical); len(problems) > 0 {
+		return fmt.Errorf("rendered pipeline steps are invalid:\n%s\n\n%s", strings.Join(problems, "\n"), canonical)
+	}
+
+	if err := d.Set("yaml", canonical); err != nil {
+		return fmt.Errorf("error setting yaml: %s", err)
+	}
+
+	d.SetId(dataSourceID(canonical))
+	return nil
+}
+
+// Construct a Terraform schema definition for an ordered list of steps, where
+// each step holds exactly one typed block; groups cannot be nested.
+func schemaPipelineStepList(nested bool) *schema.Schema {
+	types := map[string]*schema.Resource{
+		"block":   schemaPipelineBlockStep(true),
+		"command": schemaPipelineCommandStep(),
+		"input":   schemaPipelineBlockStep(false),
+		"trigger": schemaPipelineTriggerStep(),
+		"wait":    schemaPipelineWaitStep(),
+	}
+	if !nested {
+		types["group"] = schemaPipelineGroupStep()
+	}
+
+	names := []string{}
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	step := map[string]*schema.Schema{}
+	for name, elem := range types {
+		step[name] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     elem,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("Ordered steps, each with exactly one of: %s", strings.Join(names, ", ")),
+		Required:    true,
+		MinItems:    1,
+		Elem:        &schema.Resource{Schema: step},
+	}
+}
+
+// Attributes shared by every type of step.
+func schemaPipelineStepCommon() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"allow_dependency_failure": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"branches": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"depends_on": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"if": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"key": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+// Construct the schema for a command step.
+func schemaPipelineCommandStep() *schema.Resource {
+	attributes := schemaPipelineStepCommon()
+	attributes["agents"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	attributes["artifact_paths"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	attributes["commands"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	attributes["concurrency"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	}
+	attributes["concurrency_group"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	attributes["env"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	attributes["label"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	attributes["parallelism"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	}
+	attributes["plugin"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"configuration": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Plugin configuration as YAML or JSON, such as from jsonencode()",
+					Optional:    true,
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Plugin reference, such as docker-compose#v3.0.0",
+					Required:    true,
+				},
+			},
+		},
+	}
+	attributes["priority"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	}
+	attributes["retry"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"automatic": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"exit_status": &schema.Schema{
+								Type:        schema.TypeString,
+								Description: "Exit status to retry, or * for any failure",
+								Optional:    true,
+								Default:     "*",
+							},
+							"limit": &schema.Schema{
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  2,
+							},
+						},
+					},
+				},
+				"manual_allowed": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"manual_permit_on_passed": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"manual_reason": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+	attributes["soft_fail"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	attributes["soft_fail_exit_statuses"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeInt},
+	}
+	attributes["timeout_in_minutes"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	}
+	return &schema.Resource{Schema: attributes}
+}
+
+// Construct the schema for a wait step.
+func schemaPipelineWaitStep() *schema.Resource {
+	attributes := schemaPipelineStepCommon()
+	attributes["continue_on_failure"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	return &schema.Resource{Schema: attributes}
+}
+
+// Construct the schema for a block step, or an input step when not blocking.
+func schemaPipelineBlockStep(blocking bool) *schema.Resource {
+	attributes := schemaPipelineStepCommon()
+	if blocking {
+		attributes["blocked_state"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+	attributes["field"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"default": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"hint": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"key": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"multiple": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"option": &schema.Schema{
+					Type:        schema.TypeList,
+					Description: "Options that turn the field into a select field",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"label": &schema.Schema{
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"value": &schema.Schema{
+								Type:     schema.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+				"required": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"text": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "Label of the field",
+					Required:    true,
+				},
+			},
+		},
+	}
+	attributes["label"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	attributes["prompt"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	return &schema.Resource{Schema: attributes}
+}
+
+// Construct the schema for a trigger step.
+func schemaPipelineTriggerStep() *schema.Resource {
+	attributes := schemaPipelineStepCommon()
+	attributes["async"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	attributes["build"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"branch": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"commit": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"env": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"message": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"meta_data": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+	attributes["label"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	attributes["soft_fail"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	attributes["trigger"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Slug of the pipeline to trigger",
+		Required:    true,
+	}
+	return &schema.Resource{Schema: attributes}
+}
+
+// Construct the schema for a group step, which holds its own ungrouped steps.
+func schemaPipelineGroupStep() *schema.Resource {
+	attributes := schemaPipelineStepCommon()
+	attributes["label"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	attributes["step"] = schemaPipelineStepList(true)
+	return &schema.Resource{Schema: attributes}
+}
+
+// Render a list of step blocks to the structure of pipeline YAML.
+func renderPipelineSteps(blocks []interface{}) ([]interface{}, error) {
+	steps := []interface{}{}
+	for ix, item := range blocks {
+		block, _ := item.(map[string]interface{})
+
+		var rendered []interface{}
+		for _, stepType := range []string{"block", "command", "group", "input", "trigger", "wait"} {
+			typed, _ := block[stepType].([]interface{})
+			if len(typed) == 0 {
+				continue
+			}
+			// An empty block, such as a plain wait step, has no attributes
+			attributes, _ := typed[0].(map[string]interface{})
+			if attributes == nil {
+				attributes = schemaPipelineStepDefaults(stepType)
+			}
+
+			step, err := renderPipelineStep(stepType, attributes)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %s", ix+1, err)
+			}
+			rendered = append(rendered, step)
+		}
+		if len(rendered) != 1 {
+			return nil, fmt.Errorf("step %d must contain exactly one of block, command, group, input, trigger or wait", ix+1)
+		}
+		steps = append(steps, rendered[0])
+	}
+	return steps, nil
+}
+
+// Construct the zero-valued attributes of a step block given without any attributes.
+func schemaPipelineStepDefaults(stepType string) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	elem := schemaPipelineStepList(false).Elem.(*schema.Resource).Schema[stepType].Elem.(*schema.Resource)
+	for key, attribute := range elem.Schema {
+		switch attribute.Type {
+		case schema.TypeBool:
+			attributes[key] = false
+		case schema.TypeInt:
+			attributes[key] = 0
+		case schema.TypeList:
+			attributes[key] = []interface{}{}
+		case schema.TypeMap:
+			attributes[key] = map[string]interface{}{}
+		default:
+			attributes[key] = ""
+		}
+	}
+	return attributes
+}
+
+// Render a single typed step block to the structure of pipeline YAML.
+func renderPipelineStep(stepType string, block map[string]interface{}) (map[string]interface{}, error) {
+	step := map[string]interface{}{}
+	setStepValue(step, "allow_dependency_failure", block["allow_dependency_failure"])
+	setStepValue(step, "depends_on", block["depends_on"])
+	setStepValue(step, "if", block["if"])
+	setStepValue(step, "key", block["key"])
+	if branches, _ := block["branches"].([]interface{}); len(branches) > 0 {
+		names := []string{}
+		for _, branch := range branches {
+			names = append(names, branch.(string))
+		}
+		step["branches"] = strings.Join(names, " ")
+	}
+
+	switch stepType {
+	case "command":
+		setStepValue(step, "agents", block["agents"])
+		setStepValue(step, "artifact_paths", block["artifact_paths"])
+		setStepValue(step, "commands", block["commands"])
+		setStepValue(step, "concurrency", block["concurrency"])
+		setStepValue(step, "concurrency_group", block["concurrency_group"])
+		setStepValue(step, "env", block["env"])
+		setStepValue(step, "label", block["label"])
+		setStepValue(step, "parallelism", block["parallelism"])
+		setStepValue(step, "priority", block["priority"])
+		setStepValue(step, "timeout_in_minutes", block["timeout_in_minutes"])
+
+		plugins := []interface{}{}
+		for _, item := range block["plugin"].([]interface{}) {
+			plugin := item.(map[string]interface{})
+			var configuration interface{}
+			if source := plugin["configuration"].(string); source != "" {
+				if err := yaml.Unmarshal([]byte(source), &configuration); err != nil {
+					return nil, fmt.Errorf("configuration of plugin %q is not valid YAML or JSON: %s", plugin["name"], err)
+				}
+			}
+			plugins = append(plugins, map[string]interface{}{plugin["name"].(string): configuration})
+		}
+		setStepValue(step, "plugins", plugins)
+
+		if retries, _ := block["retry"].([]interface{}); len(retries) > 0 && retries[0] != nil {
+			retry := retries[0].(map[string]interface{})
+			result := map[string]interface{}{}
+			automatic := []interface{}{}
+			for _, item := range retry["automatic"].([]interface{}) {
+				rule := item.(map[string]interface{})
+				automatic = append(automatic, map[string]interface{}{
+					"exit_status": stepExitStatus(rule["exit_status"].(string)),
+					"limit":       rule["limit"],
+				})
+			}
+			setStepValue(result, "automatic", automatic)
+			manual := map[string]interface{}{}
+			if !retry["manual_allowed"].(bool) {
+				manual["allowed"] = false
+			}
+			setStepValue(manual, "permit_on_passed", retry["manual_permit_on_passed"])
+			setStepValue(manual, "reason", retry["manual_reason"])
+			setStepValue(result, "manual", manual)
+			setStepValue(step, "retry", result)
+		}
+
+		if statuses, _ := block["soft_fail_exit_statuses"].([]interface{}); len(statuses) > 0 {
+			softFail := []interface{}{}
+			for _, status := range statuses {
+				softFail = append(softFail, map[string]interface{}{"exit_status": status})
+			}
+			step["soft_fail"] = softFail
+		} else {
+			setStepValue(step, "soft_fail", block["soft_fail"])
+		}
+	case "wait":
+		step["wait"] = nil
+		setStepValue(step, "continue_on_failure", block["continue_on_failure"])
+	case "block", "input":
+		step[stepType] = block["label"]
+		setStepValue(step, "blocked_state", block["blocked_state"])
+		setStepValue(step, "prompt", block["prompt"])
+
+		fields := []interface{}{}
+		for _, item := range block["field"].([]interface{}) {
+			field := item.(map[string]interface{})
+			result := map[string]interface{}{
+				"key":      field["key"],
+				"required": field["required"],
+			}
+			setStepValue(result, "default", field["default"])
+			setStepValue(result, "hint", field["hint"])
+			if options, _ := field["option"].([]interface{}); len(options) > 0 {
+				result["select"] = field["text"]
+				result["options"] = options
+				setStepValue(result, "multiple", field["multiple"])
+			} else {
+				result["text"] = field["text"]
+			}
+			fields = append(fields, result)
+		}
+		setStepValue(step, "fields", fields)
+	case "trigger":
+		step["trigger"] = block["trigger"]
+		setStepValue(step, "async", block["async"])
+		setStepValue(step, "label", block["label"])
+		setStepValue(step, "soft_fail", block["soft_fail"])
+		if builds, _ := block["build"].([]interface{}); len(builds) > 0 && builds[0] != nil {
+			build := builds[0].(map[string]interface{})
+			result := map[string]interface{}{}
+			for _, key := range []string{"branch", "commit", "env", "message", "meta_data"} {
+				setStepValue(result, key, build[key])
+			}
+			setStepValue(step, "build", result)
+		}
+	case "group":
+		steps, err := renderPipelineSteps(block["step"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %s", block["label"], err)
+		}
+		step["group"] = block["label"]
+		step["steps"] = steps
+	}
+	return step, nil
+}
+
+// Set a step attribute only when it has a meaningful value.
+func setStepValue(step map[string]interface{}, key string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case string:
+		if v == "" {
+			return
+		}
+	case bool:
+		if !v {
+			return
+		}
+	case int:
+		if v == 0 {
+			return
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return
+		}
+	}
+	step[key] = value
+}
+
+// Render a retry exit status as an integer where possible, keeping "*" as is.
+func stepExitStatus(status string) interface{} {
+	if code, err := strconv.Atoi(status); err == nil {
+		return code
+	}
+	return status
+}
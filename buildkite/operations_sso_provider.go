@@ -1,9 +1,13 @@
 package buildkite
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -13,6 +17,12 @@ func dataSourceSsoProviders() *schema.Resource {
 		Read: dataSourceSsoProvidersRead,
 
 		Schema: map[string]*schema.Schema{
+			"organization_slug": {
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+			},
+
 			"sso_providers": schemaSsoProviderList(DataSourceFullEntity),
 		},
 	}
@@ -21,7 +31,10 @@ func dataSourceSsoProviders() *schema.Resource {
 // Read SSO providers from the Buildkite API and convert to the Terraform schema.
 func dataSourceSsoProvidersRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	ssoProviders, err := client.readSsoProviders()
+
+	slug := d.Get("organization_slug").(string)
+
+	ssoProviders, err := client.readSsoProviders(slug)
 	if err != nil {
 		return err
 	}
@@ -35,7 +48,7 @@ func dataSourceSsoProvidersRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting sso_providers: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID("sso_providers", client.organization(slug)))
 	return err
 }
 
@@ -64,6 +77,141 @@ func schemaSsoProvider(mode SchemaMode) *schema.Resource {
 	case DataSourceFullEntity:
 		return &schema.Resource{
 			Schema: map[string]*schema.Schema{
+				"created_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"created_by": schemaUserList(DataSourceFullEntity),
+				"disabled_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"disabled_by": schemaUserList(DataSourceFullEntity),
+				"disabled_reason": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"email_domain": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"email_domain_verification_address": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"email_domain_verified_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"enabled_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"enabled_by": schemaUserList(DataSourceFullEntity),
+				"github_app": &schema.Schema{
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Settings for a GitHub app SSO provider",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"organization_name": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"google_gsuite": &schema.Schema{
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Settings for a Google G Suite SSO provider",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"hosted_domain": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"note": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"saml": &schema.Schema{
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Settings for a SAML SSO provider",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"digest_method": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"idp_certificate": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"idp_certificate_fingerprint": &schema.Schema{
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "SHA-256 fingerprint of the identity provider certificate",
+							},
+							"idp_issuer": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"idp_metadata_url": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"idp_sso_url": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"signature_method": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"sp_issuer": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"sp_metadata_url": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"sp_sso_url": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"session_duration_in_hours": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"state": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"test_authorization_required": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"type": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"url": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
 				"uuid": &schema.Schema{
 					Type:     schema.TypeString,
 					Computed: true,
@@ -95,6 +243,27 @@ type SsoProvider struct {
 	Type                           string
 	URL                            string
 	UUID                           string
+
+	// Only populated for SAML providers
+	DigestMethod     string
+	IdentityProvider struct {
+		Certificate string
+		Issuer      string
+		Metadata    struct{ URL string }
+		SsoURL      string
+	}
+	ServiceProvider struct {
+		Issuer      string
+		MetadataURL string
+		SsoURL      string
+	}
+	SignatureMethod string
+
+	// Only populated for Google G Suite providers
+	GoogleHostedDomain string
+
+	// Only populated for GitHub app providers
+	GithubOrganizationName string
 }
 
 // SsoProviderList defines the properties on the Buildkite API to map to Terraform.
@@ -103,6 +272,7 @@ type SsoProviderList struct {
 	Edges []struct {
 		Node SsoProvider
 	}
+	PageInfo PageInfo
 }
 
 // Convert a Buildkite API type to a Terraform structure.
@@ -113,8 +283,55 @@ func (source *SsoProvider) convert(mode SchemaMode) map[string]interface{} {
 			"uuid": source.UUID,
 		}
 	case DataSourceFullEntity:
+		githubApp := []interface{}{}
+		googleGsuite := []interface{}{}
+		saml := []interface{}{}
+		switch source.Type {
+		case "GITHUB_APP":
+			githubApp = append(githubApp, map[string]interface{}{
+				"organization_name": source.GithubOrganizationName,
+			})
+		case "GOOGLE_GSUITE":
+			googleGsuite = append(googleGsuite, map[string]interface{}{
+				"hosted_domain": source.GoogleHostedDomain,
+			})
+		case "SAML":
+			saml = append(saml, map[string]interface{}{
+				"digest_method":               source.DigestMethod,
+				"idp_certificate":             source.IdentityProvider.Certificate,
+				"idp_certificate_fingerprint": certificateFingerprint(source.IdentityProvider.Certificate),
+				"idp_issuer":                  source.IdentityProvider.Issuer,
+				"idp_metadata_url":            source.IdentityProvider.Metadata.URL,
+				"idp_sso_url":                 source.IdentityProvider.SsoURL,
+				"signature_method":            source.SignatureMethod,
+				"sp_issuer":                   source.ServiceProvider.Issuer,
+				"sp_metadata_url":             source.ServiceProvider.MetadataURL,
+				"sp_sso_url":                  source.ServiceProvider.SsoURL,
+			})
+		}
+
 		return map[string]interface{}{
-			"uuid": source.UUID,
+			"created_at":                        source.CreatedAt,
+			"created_by":                        source.CreatedBy.convertList(DataSourceFullEntity),
+			"disabled_at":                       source.DisabledAt,
+			"disabled_by":                       source.DisabledBy.convertList(DataSourceFullEntity),
+			"disabled_reason":                   source.DisabledReason,
+			"email_domain":                      source.EmailDomain,
+			"email_domain_verification_address": source.EmailDomainVerificationAddress,
+			"email_domain_verified_at":          source.EmailDomainVerifiedAt,
+			"enabled_at":                        source.EnabledAt,
+			"enabled_by":                        source.EnabledBy.convertList(DataSourceFullEntity),
+			"github_app":                        githubApp,
+			"google_gsuite":                     googleGsuite,
+			"id":                                source.ID,
+			"note":                              source.Note,
+			"saml":                              saml,
+			"session_duration_in_hours":         source.SessionDurationInHours,
+			"state":                             source.State,
+			"test_authorization_required":       source.TestAuthorizationRequired,
+			"type":                              source.Type,
+			"url":                               source.URL,
+			"uuid":                              source.UUID,
 		}
 	default:
 		return map[string]interface{}{}
@@ -129,6 +346,135 @@ func (source *SsoProviderList) convert(mode SchemaMode) (result []interface{}) {
 	return
 }
 
-func (client *Client) readSsoProviders() ([]SsoProvider, error) {
-	return nil, nil
+// Compute the colon-separated SHA-256 fingerprint of a PEM or bare base64 certificate.
+func certificateFingerprint(certificate string) string {
+	var der []byte
+	if block, _ := pem.Decode([]byte(certificate)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(certificate), ""))
+		if err != nil {
+			return ""
+		}
+		der = decoded
+	}
+	if len(der) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256(der)
+	parts := make([]string, len(sum))
+	for ix, b := range sum {
+		parts[ix] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// Retrieve the fields of an SSO provider, including the type-specific settings.
+const fieldsSsoProvider = "createdAt " +
+	"createdBy { " + fieldsUser + " } " +
+	"disabledAt " +
+	"disabledBy { " + fieldsUser + " } " +
+	"disabledReason " +
+	"emailDomain " +
+	"emailDomainVerificationAddress " +
+	"emailDomainVerifiedAt " +
+	"enabledAt " +
+	"enabledBy { " + fieldsUser + " } " +
+	"id " +
+	"note " +
+	"sessionDurationInHours " +
+	"state " +
+	"testAuthorizationRequired " +
+	"type " +
+	"url " +
+	"uuid " +
+	"... on SSOProviderSAML { " +
+	"digestMethod " +
+	"identityProvider { certificate issuer metadata { url } ssoURL } " +
+	"serviceProvider { issuer metadataURL ssoURL } " +
+	"signatureMethod } " +
+	"... on SSOProviderGoogleGSuite { googleHostedDomain } " +
+	"... on SSOProviderGitHubApp { githubOrganizationName }"
+
+// Retrieve one page of SSO providers for an organization.
+const queryOrganizationSsoProviders = "organization(slug: %s) { " +
+	"ssoProviders(first: %d, after: %s) { " +
+	"edges { node { " + fieldsSsoProvider + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Read a list of all SSO providers for an organization from the Buildkite API.
+func (client *Client) readSsoProviders(slug string) ([]SsoProvider, error) {
+	var ssoProviders []SsoProvider
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ SsoProviders SsoProviderList }
+		err := client.Query(&page, queryOrganizationSsoProviders,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.SsoProviders.Edges {
+			ssoProviders = append(ssoProviders, edge.Node)
+		}
+		return page.SsoProviders.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ssoProviders, func(i, j int) bool {
+		return ssoProviders[i].UUID < ssoProviders[j].UUID
+	})
+	return ssoProviders, nil
+}
+
+// Retrieve an SSO provider by its GraphQL identifier.
+const queryNodeSsoProvider = "node(id: %s) { ... on SSOProvider { " + fieldsSsoProvider + " } }"
+
+// Create an SSO provider from a GraphQL input object.
+const mutationSsoProviderCreate = "ssoProviderCreate(input: %s) { ssoProvider { " + fieldsSsoProvider + " } }"
+
+// Update an SSO provider from a GraphQL input object.
+const mutationSsoProviderUpdate = "ssoProviderUpdate(input: %s) { ssoProvider { " + fieldsSsoProvider + " } }"
+
+// Delete an SSO provider by its GraphQL identifier.
+const mutationSsoProviderDelete = "ssoProviderDelete(input: { id: %s }) { clientMutationId }"
+
+// Read a single SSO provider from the Buildkite API by its GraphQL identifier.
+func (client *Client) readSsoProvider(id string) (*SsoProvider, error) {
+	var ssoProvider SsoProvider
+	if err := client.Query(&ssoProvider, queryNodeSsoProvider, quote(id)); err != nil {
+		return nil, err
+	}
+	if ssoProvider.ID == "" {
+		return nil, errNotFound
+	}
+	return &ssoProvider, nil
+}
+
+// Create an SSO provider through the Buildkite API.
+func (client *Client) createSsoProvider(input map[string]interface{}) (*SsoProvider, error) {
+	var payload struct{ SsoProvider SsoProvider }
+	if err := client.Mutate(&payload, mutationSsoProviderCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.SsoProvider, nil
+}
+
+// Update an SSO provider through the Buildkite API.
+func (client *Client) updateSsoProvider(input map[string]interface{}) (*SsoProvider, error) {
+	var payload struct{ SsoProvider SsoProvider }
+	if err := client.Mutate(&payload, mutationSsoProviderUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.SsoProvider, nil
+}
+
+// Delete an SSO provider through the Buildkite API.
+func (client *Client) deleteSsoProvider(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationSsoProviderDelete, quote(id))
 }
@@ -2,8 +2,8 @@ package buildkite
 
 import (
 	"fmt"
+	"sort"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -13,6 +13,12 @@ func dataSourceTeams() *schema.Resource {
 		Read: dataSourceTeamsRead,
 
 		Schema: map[string]*schema.Schema{
+			"organization_slug": {
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+			},
+
 			"teams": schemaTeamList(DataSourceFullEntity),
 		},
 	}
@@ -21,7 +27,10 @@ func dataSourceTeams() *schema.Resource {
 // Read teams from the Buildkite API and convert to the Terraform schema.
 func dataSourceTeamsRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	teams, err := client.readTeams()
+
+	slug := d.Get("organization_slug").(string)
+
+	teams, err := client.readTeams(slug)
 	if err != nil {
 		return err
 	}
@@ -35,10 +44,60 @@ func dataSourceTeamsRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting teams: %s", err)
 	}
 
-	d.SetId(resource.UniqueId())
+	d.SetId(dataSourceID("teams", client.organization(slug)))
 	return err
 }
 
+// Define a Terraform data source for a single team.
+func dataSourceTeam() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTeamRead,
+
+		Schema: schemaDataSourceLookup(schemaTeam(DataSourceFullEntity), "name", "slug", "uuid"),
+	}
+}
+
+// Look up a single team from the Buildkite API and convert to the Terraform schema.
+func dataSourceTeamRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := d.Get("organization_slug").(string)
+
+	var team map[string]interface{}
+	if value, ok := d.GetOk("slug"); ok {
+		item, err := client.readTeamBySlug(slug, value.(string))
+		if isNotFound(err) {
+			return fmt.Errorf("no team found with slug %q", value)
+		}
+		if err != nil {
+			return err
+		}
+		team = item.convert(DataSourceFullEntity)
+	} else {
+		// Teams can only be queried directly by slug, so search for the name or UUID
+		teams, err := client.readTeams(slug)
+		if err != nil {
+			return err
+		}
+
+		list := []map[string]interface{}{}
+		for _, item := range teams {
+			list = append(list, item.convert(DataSourceFullEntity))
+		}
+
+		team, err = findEntity(d, "team", list, "name", "slug", "uuid")
+		if err != nil {
+			return err
+		}
+	}
+	if err := setResourceData(d, team); err != nil {
+		return err
+	}
+
+	d.SetId(team["id"].(string))
+	return nil
+}
+
 // Construct a Terraform schema definition for a list of teams.
 func schemaTeamList(mode SchemaMode) *schema.Schema {
 	return &schema.Schema{
@@ -64,6 +123,43 @@ func schemaTeam(mode SchemaMode) *schema.Resource {
 	case DataSourceFullEntity:
 		return &schema.Resource{
 			Schema: map[string]*schema.Schema{
+				"created_at": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"created_by": schemaUserList(DataSourceFullEntity),
+				"default_member_role": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"is_default_team": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"members_can_create_pipelines": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"privacy": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"slug": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
 				"uuid": &schema.Schema{
 					Type:     schema.TypeString,
 					Computed: true,
@@ -77,42 +173,60 @@ func schemaTeam(mode SchemaMode) *schema.Resource {
 
 // Team defines the properties on the Buildkite API to map to Terraform.
 type Team struct {
-	CreatedAt         string
-	CreatedBy         User
-	DefaultMemberRole string
-	Description       string
-	ID                string
-	IsDefaultTeam     bool
-	//Members                   []TeamMember
+	CreatedAt                 string
+	CreatedBy                 User
+	DefaultMemberRole         string
+	Description               string
+	ID                        string
+	IsDefaultTeam             bool
+	Members                   TeamMemberList
 	MembersCanCreatePipelines bool
 	Name                      string
-	//Pipelines                 []TeamPipeline
-	Privacy string
-	Slug    string
-	UUID    string
+	Pipelines                 TeamPipelineList
+	Privacy                   string
+	Slug                      string
+	UUID                      string
 }
 
-/*
+// TeamMember defines the properties on the Buildkite API to map to Terraform.
 type TeamMember struct {
-	CreatedAt graphql.String
+	CreatedAt string
 	CreatedBy User
-	ID        graphql.ID
-	Role      graphql.String
+	ID        string
+	Role      string
 	Team      Team
 	User      User
-	UUID      graphql.ID
+	UUID      string
+}
+
+// TeamMemberList defines the properties on the Buildkite API to map to Terraform.
+type TeamMemberList struct {
+	Count int
+	Edges []struct {
+		Node TeamMember
+	}
+	PageInfo PageInfo
 }
 
+// TeamPipeline defines the properties on the Buildkite API to map to Terraform.
 type TeamPipeline struct {
-	AccessLevel graphql.String
-	CreatedAt   graphql.String
+	AccessLevel string
+	CreatedAt   string
 	CreatedBy   User
-	ID          graphql.ID
+	ID          string
 	Pipeline    Pipeline
 	Team        Team
-	UUID        graphql.ID
+	UUID        string
+}
+
+// TeamPipelineList defines the properties on the Buildkite API to map to Terraform.
+type TeamPipelineList struct {
+	Count int
+	Edges []struct {
+		Node TeamPipeline
+	}
+	PageInfo PageInfo
 }
-*/
 
 // TeamList defines the properties on the Buildkite API to map to Terraform.
 type TeamList struct {
@@ -120,6 +234,7 @@ type TeamList struct {
 	Edges []struct {
 		Node Team
 	}
+	PageInfo PageInfo
 }
 
 // Convert a Buildkite API type to a Terraform structure.
@@ -131,7 +246,17 @@ func (source *Team) convert(mode SchemaMode) map[string]interface{} {
 		}
 	case DataSourceFullEntity:
 		return map[string]interface{}{
-			"uuid": source.UUID,
+			"created_at":                   source.CreatedAt,
+			"created_by":                   source.CreatedBy.convertList(DataSourceFullEntity),
+			"default_member_role":          source.DefaultMemberRole,
+			"description":                  source.Description,
+			"id":                           source.ID,
+			"is_default_team":              source.IsDefaultTeam,
+			"members_can_create_pipelines": source.MembersCanCreatePipelines,
+			"name":                         source.Name,
+			"privacy":                      source.Privacy,
+			"slug":                         source.Slug,
+			"uuid":                         source.UUID,
 		}
 	default:
 		return map[string]interface{}{}
@@ -146,6 +271,354 @@ func (source *TeamList) convert(mode SchemaMode) (result []interface{}) {
 	return
 }
 
-func (client *Client) readTeams() ([]Team, error) {
-	return nil, nil
+// Retrieve the fields of a team.
+const fieldsTeam = "createdAt " +
+	"createdBy { " + fieldsUser + " } " +
+	"defaultMemberRole " +
+	"description " +
+	"id " +
+	"isDefaultTeam " +
+	"membersCanCreatePipelines " +
+	"name " +
+	"privacy " +
+	"slug " +
+	"uuid"
+
+// Retrieve one page of teams for an organization.
+const queryOrganizationTeams = "organization(slug: %s) { " +
+	"teams(first: %d, after: %s) { " +
+	"edges { node { " + fieldsTeam + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Read a list of all teams for an organization from the Buildkite API.
+func (client *Client) readTeams(slug string) ([]Team, error) {
+	var teams []Team
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Teams TeamList }
+		err := client.Query(&page, queryOrganizationTeams,
+			quote(client.organization(slug)),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Teams.Edges {
+			teams = append(teams, edge.Node)
+		}
+		return page.Teams.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Slug < teams[j].Slug
+	})
+	return teams, nil
+}
+
+// Retrieve a team by its GraphQL identifier.
+const queryNodeTeam = "node(id: %s) { ... on Team { " + fieldsTeam + " } }"
+
+// Retrieve a team by its "organization/team" slug.
+const queryTeam = "team(slug: %s) { " + fieldsTeam + " }"
+
+// Create a team from a GraphQL input object.
+const mutationTeamCreate = "teamCreate(input: %s) { teamEdge { node { " + fieldsTeam + " } } }"
+
+// Update a team from a GraphQL input object.
+const mutationTeamUpdate = "teamUpdate(input: %s) { team { " + fieldsTeam + " } }"
+
+// Delete a team by its GraphQL identifier.
+const mutationTeamDelete = "teamDelete(input: { id: %s }) { clientMutationId }"
+
+// Read a single team from the Buildkite API by its GraphQL identifier.
+func (client *Client) readTeam(id string) (*Team, error) {
+	var team Team
+	if err := client.Query(&team, queryNodeTeam, quote(id)); err != nil {
+		return nil, err
+	}
+	if team.ID == "" {
+		return nil, errNotFound
+	}
+	return &team, nil
+}
+
+// Read a single team from the Buildkite API by its organization and team slugs.
+func (client *Client) readTeamBySlug(organization string, slug string) (*Team, error) {
+	var team Team
+	err := client.Query(&team, queryTeam, quote(client.organization(organization)+"/"+slug))
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// Create a team through the Buildkite API.
+func (client *Client) createTeam(input map[string]interface{}) (*Team, error) {
+	var payload struct {
+		TeamEdge struct{ Node Team }
+	}
+	if err := client.Mutate(&payload, mutationTeamCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.TeamEdge.Node, nil
+}
+
+// Update a team through the Buildkite API.
+func (client *Client) updateTeam(input map[string]interface{}) (*Team, error) {
+	var payload struct{ Team Team }
+	if err := client.Mutate(&payload, mutationTeamUpdate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.Team, nil
+}
+
+// Delete a team through the Buildkite API.
+func (client *Client) deleteTeam(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationTeamDelete, quote(id))
+}
+
+// Retrieve the fields of a team member.
+const fieldsTeamMember = "createdAt " +
+	"id " +
+	"role " +
+	"team { id slug } " +
+	"user { " + fieldsUser + " } " +
+	"uuid"
+
+// Retrieve a team member by its GraphQL identifier.
+const queryNodeTeamMember = "node(id: %s) { ... on TeamMember { " + fieldsTeamMember + " } }"
+
+// Retrieve one page of members for a team by its "organization/team" slug.
+const queryTeamMembers = "team(slug: %s) { " +
+	"members(first: %d, after: %s) { " +
+	"edges { node { " + fieldsTeamMember + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Retrieve one page of members for a team by its GraphQL identifier.
+const queryNodeTeamMembers = "node(id: %s) { ... on Team { " +
+	"members(first: %d, after: %s) { " +
+	"edges { node { " + fieldsTeamMember + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Create a team member from a GraphQL input object.
+const mutationTeamMemberCreate = "teamMemberCreate(input: %s) { " +
+	"teamMemberEdge { node { " + fieldsTeamMember + " } } }"
+
+// Update the role of a team member.
+const mutationTeamMemberUpdate = "teamMemberUpdate(input: { id: %s, role: %s }) { " +
+	"teamMember { " + fieldsTeamMember + " } }"
+
+// Delete a team member by its GraphQL identifier.
+const mutationTeamMemberDelete = "teamMemberDelete(input: { id: %s }) { clientMutationId }"
+
+// Read a single team member from the Buildkite API by its GraphQL identifier.
+func (client *Client) readTeamMember(id string) (*TeamMember, error) {
+	var member TeamMember
+	if err := client.Query(&member, queryNodeTeamMember, quote(id)); err != nil {
+		return nil, err
+	}
+	if member.ID == "" {
+		return nil, errNotFound
+	}
+	return &member, nil
+}
+
+// Read all members of a team from the Buildkite API by its organization and team slugs.
+func (client *Client) readTeamMembers(organization string, slug string) ([]TeamMember, error) {
+	var members []TeamMember
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Members TeamMemberList }
+		err := client.Query(&page, queryTeamMembers,
+			quote(client.organization(organization)+"/"+slug),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Members.Edges {
+			members = append(members, edge.Node)
+		}
+		return page.Members.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Read all members of a team from the Buildkite API by its GraphQL identifier.
+func (client *Client) readTeamMembersByID(id string) ([]TeamMember, error) {
+	var members []TeamMember
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Members TeamMemberList }
+		err := client.Query(&page, queryNodeTeamMembers, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Members.Edges {
+			members = append(members, edge.Node)
+		}
+		return page.Members.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].User.ID < members[j].User.ID
+	})
+	return members, nil
+}
+
+// Create a team member through the Buildkite API.
+func (client *Client) createTeamMember(input map[string]interface{}) (*TeamMember, error) {
+	var payload struct {
+		TeamMemberEdge struct{ Node TeamMember }
+	}
+	if err := client.Mutate(&payload, mutationTeamMemberCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.TeamMemberEdge.Node, nil
+}
+
+// Update the role of a team member through the Buildkite API.
+func (client *Client) updateTeamMember(id string, role string) (*TeamMember, error) {
+	var payload struct{ TeamMember TeamMember }
+	if err := client.Mutate(&payload, mutationTeamMemberUpdate, quote(id), role); err != nil {
+		return nil, err
+	}
+	return &payload.TeamMember, nil
+}
+
+// Delete a team member through the Buildkite API.
+func (client *Client) deleteTeamMember(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationTeamMemberDelete, quote(id))
+}
+
+// Retrieve the fields of a team pipeline.
+const fieldsTeamPipeline = "accessLevel " +
+	"createdAt " +
+	"id " +
+	"pipeline { id slug } " +
+	"team { id slug } " +
+	"uuid"
+
+// Retrieve a team pipeline by its GraphQL identifier.
+const queryNodeTeamPipeline = "node(id: %s) { ... on TeamPipeline { " + fieldsTeamPipeline + " } }"
+
+// Retrieve one page of pipelines for a team by its "organization/team" slug.
+const queryTeamPipelines = "team(slug: %s) { " +
+	"pipelines(first: %d, after: %s) { " +
+	"edges { node { " + fieldsTeamPipeline + " } } " +
+	"pageInfo { endCursor hasNextPage } } }"
+
+// Retrieve one page of team grants for a pipeline by its GraphQL identifier.
+const queryPipelineTeams = "node(id: %s) { ... on Pipeline { " +
+	"teams(first: %d, after: %s) { " +
+	"edges { node { " + fieldsTeamPipeline + " } } " +
+	"pageInfo { endCursor hasNextPage } } } }"
+
+// Create a team pipeline from a GraphQL input object.
+const mutationTeamPipelineCreate = "teamPipelineCreate(input: %s) { " +
+	"teamPipelineEdge { node { " + fieldsTeamPipeline + " } } }"
+
+// Update the access level of a team pipeline.
+const mutationTeamPipelineUpdate = "teamPipelineUpdate(input: { id: %s, accessLevel: %s }) { " +
+	"teamPipeline { " + fieldsTeamPipeline + " } }"
+
+// Delete a team pipeline by its GraphQL identifier.
+const mutationTeamPipelineDelete = "teamPipelineDelete(input: { id: %s }) { clientMutationId }"
+
+// Read a single team pipeline from the Buildkite API by its GraphQL identifier.
+func (client *Client) readTeamPipeline(id string) (*TeamPipeline, error) {
+	var teamPipeline TeamPipeline
+	if err := client.Query(&teamPipeline, queryNodeTeamPipeline, quote(id)); err != nil {
+		return nil, err
+	}
+	if teamPipeline.ID == "" {
+		return nil, errNotFound
+	}
+	return &teamPipeline, nil
+}
+
+// Read all pipelines of a team from the Buildkite API by its organization and team slugs.
+func (client *Client) readTeamPipelines(organization string, slug string) ([]TeamPipeline, error) {
+	var teamPipelines []TeamPipeline
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Pipelines TeamPipelineList }
+		err := client.Query(&page, queryTeamPipelines,
+			quote(client.organization(organization)+"/"+slug),
+			pageSize,
+			after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Pipelines.Edges {
+			teamPipelines = append(teamPipelines, edge.Node)
+		}
+		return page.Pipelines.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return teamPipelines, nil
+}
+
+// Read all team grants of a pipeline from the Buildkite API by its GraphQL identifier.
+func (client *Client) readPipelineTeams(id string) ([]TeamPipeline, error) {
+	var teamPipelines []TeamPipeline
+	err := client.queryPages(func(after string) (PageInfo, error) {
+		var page struct{ Teams TeamPipelineList }
+		err := client.Query(&page, queryPipelineTeams, quote(id), pageSize, after)
+		if err != nil {
+			return PageInfo{}, err
+		}
+
+		for _, edge := range page.Teams.Edges {
+			teamPipelines = append(teamPipelines, edge.Node)
+		}
+		return page.Teams.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(teamPipelines, func(i, j int) bool {
+		return teamPipelines[i].Team.ID < teamPipelines[j].Team.ID
+	})
+	return teamPipelines, nil
+}
+
+// Create a team pipeline through the Buildkite API.
+func (client *Client) createTeamPipeline(input map[string]interface{}) (*TeamPipeline, error) {
+	var payload struct {
+		TeamPipelineEdge struct{ Node TeamPipeline }
+	}
+	if err := client.Mutate(&payload, mutationTeamPipelineCreate, literal(input)); err != nil {
+		return nil, err
+	}
+	return &payload.TeamPipelineEdge.Node, nil
+}
+
+// Update the access level of a team pipeline through the Buildkite API.
+func (client *Client) updateTeamPipeline(id string, accessLevel string) (*TeamPipeline, error) {
+	var payload struct{ TeamPipeline TeamPipeline }
+	if err := client.Mutate(&payload, mutationTeamPipelineUpdate, quote(id), accessLevel); err != nil {
+		return nil, err
+	}
+	return &payload.TeamPipeline, nil
+}
+
+// Delete a team pipeline through the Buildkite API.
+func (client *Client) deleteTeamPipeline(id string) error {
+	var payload struct{}
+	return client.Mutate(&payload, mutationTeamPipelineDelete, quote(id))
 }
@@ -1,6 +1,12 @@
 package buildkite
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -40,22 +46,40 @@ func Provider() *schema.Provider {
 		ConfigureFunc: providerConfigure,
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"buildkite_agents":        dataSourceAgents(),
-			"buildkite_builds":        dataSourceBuilds(),
-			"buildkite_members":       dataSourceMembers(),
-			"buildkite_organizations": dataSourceOrganizations(),
-			"buildkite_pipelines":     dataSourcePipelines(),
-			"buildkite_sso_providers": dataSourceSsoProviders(),
-			"buildkite_teams":         dataSourceTeams(),
+			"buildkite_agent":             dataSourceAgent(),
+			"buildkite_agents":            dataSourceAgents(),
+			"buildkite_audit_events":      dataSourceAuditEvents(),
+			"buildkite_build_annotations": dataSourceBuildAnnotations(),
+			"buildkite_build_artifacts":   dataSourceBuildArtifacts(),
+			"buildkite_build_jobs":        dataSourceBuildJobs(),
+			"buildkite_builds":            dataSourceBuilds(),
+			"buildkite_member":            dataSourceMember(),
+			"buildkite_members":           dataSourceMembers(),
+			"buildkite_organizations":     dataSourceOrganizations(),
+			"buildkite_pipeline":          dataSourcePipeline(),
+			"buildkite_pipeline_steps":    dataSourcePipelineSteps(),
+			"buildkite_pipelines":         dataSourcePipelines(),
+			"buildkite_sso_providers":     dataSourceSsoProviders(),
+			"buildkite_team":              dataSourceTeam(),
+			"buildkite_teams":             dataSourceTeams(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"buildkite_pipeline":          resourcePipelineSchedule(),
-			"buildkite_pipeline_schedule": resourcePipelineSchedule(),
-			"buildkite_sso_provider":      resourceSsoProvider(),
-			"buildkite_team":              resourceTeam(),
-			"buildkite_team_member":       resourceTeamMember(),
-			"buildkite_team_pipeline":     resourceTeamPipeline(),
+			"buildkite_agent_token":             resourceAgentToken(),
+			"buildkite_build":                   resourceBuild(),
+			"buildkite_cluster":                 resourceCluster(),
+			"buildkite_cluster_agent_token":     resourceClusterAgentToken(),
+			"buildkite_cluster_queue":           resourceClusterQueue(),
+			"buildkite_organization_invitation": resourceOrganizationInvitation(),
+			"buildkite_organization_member":     resourceOrganizationMember(),
+			"buildkite_pipeline":                resourcePipeline(),
+			"buildkite_pipeline_schedule":       resourcePipelineSchedule(),
+			"buildkite_sso_provider":            resourceSsoProvider(),
+			"buildkite_team":                    resourceTeam(),
+			"buildkite_team_member":             resourceTeamMember(),
+			"buildkite_team_members":            resourceTeamMembers(),
+			"buildkite_team_pipeline":           resourceTeamPipeline(),
+			"buildkite_team_pipelines":          resourceTeamPipelines(),
 		},
 	}
 }
@@ -66,3 +90,111 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 	return NewClient(apiToken, organizationSlug), nil
 }
+
+// Construct the schema for a data source that looks up a single entity by
+// exactly one of the given attributes of the entity's full schema.
+func schemaDataSourceLookup(entity *schema.Resource, lookups ...string) map[string]*schema.Schema {
+	result := map[string]*schema.Schema{
+		"organization_slug": {
+			Type:        schema.TypeString,
+			Description: "Buildkite organization slug",
+			Optional:    true,
+		},
+	}
+	for key, value := range entity.Schema {
+		result[key] = value
+	}
+	for _, key := range lookups {
+		lookup := *entity.Schema[key]
+		lookup.Optional = true
+		lookup.ExactlyOneOf = lookups
+		result[key] = &lookup
+	}
+	return result
+}
+
+// Find the single converted entity that matches the lookup attribute set on a data source.
+func findEntity(d *schema.ResourceData, kind string, entities []map[string]interface{}, lookups ...string) (map[string]interface{}, error) {
+	for _, key := range lookups {
+		value, ok := d.GetOk(key)
+		if !ok {
+			continue
+		}
+
+		var matches []map[string]interface{}
+		for _, entity := range entities {
+			if strings.EqualFold(fmt.Sprintf("%v", entity[key]), fmt.Sprintf("%v", value)) {
+				matches = append(matches, entity)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no %s found with %s %q", kind, key, value)
+		case 1:
+			return matches[0], nil
+		default:
+			return nil, fmt.Errorf("%d %ss found with %s %q, expected exactly one", len(matches), kind, key, value)
+		}
+	}
+	return nil, fmt.Errorf("one of %s must be set to look up a %s", strings.Join(lookups, ", "), kind)
+}
+
+// Set every attribute of a converted entity on the Terraform resource data.
+func setResourceData(d *schema.ResourceData, values map[string]interface{}) error {
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error setting %s: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// Derive a stable data source identifier from its query inputs or sorted results.
+func dataSourceID(values ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Suppress differences in case, such as between email addresses as written and as stored.
+func suppressCaseDifference(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// List the keys of a string map in sorted order, for deterministic API calls.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set the attributes of a converted entity that are part of a resource schema.
+func setResourceState(d *schema.ResourceData, r *schema.Resource, values map[string]interface{}) error {
+	for key, value := range values {
+		if _, ok := r.Schema[key]; !ok {
+			continue
+		}
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error setting %s: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// Split an import identifier into the slash-separated parts described by format.
+func parseImportID(id string, format string) ([]string, error) {
+	count := len(strings.Split(format, "/"))
+	parts := strings.SplitN(id, "/", count)
+	if len(parts) != count {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected %s", id, format)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected %s", id, format)
+		}
+	}
+	return parts, nil
+}
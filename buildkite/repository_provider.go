@@ -0,0 +1,215 @@
+package buildkite
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// Provider settings accepted by each kind of repository provider, keyed by
+// the provider identifier reported by the REST API.
+var repositoryProviderSettings = map[string][]string{
+	"bitbucket": {
+		"build_branches",
+		"build_pull_requests",
+		"build_tags",
+		"publish_commit_status",
+		"publish_commit_status_per_step",
+		"pull_request_branch_filter_configuration",
+		"pull_request_branch_filter_enabled",
+		"skip_pull_request_builds_for_existing_commits",
+	},
+	"github": {
+		"build_branches",
+		"build_pull_requests",
+		"build_tags",
+		"publish_commit_status",
+		"publish_commit_status_per_step",
+		"pull_request_branch_filter_configuration",
+		"pull_request_branch_filter_enabled",
+		"skip_pull_request_builds_for_existing_commits",
+		"trigger_mode",
+	},
+	"gitlab": {
+		"build_branches",
+		"build_tags",
+		"publish_commit_status",
+	},
+}
+
+// Self-hosted variants of a repository provider accept the same settings.
+var repositoryProviderAliases = map[string]string{
+	"bitbucket_server":  "bitbucket",
+	"github_enterprise": "github",
+	"gitlab_ee":         "gitlab",
+}
+
+// Construct a Terraform schema definition for the repository provider settings of a pipeline.
+func schemaRepositoryProviderSettings() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: "Build trigger settings for the repository provider of the pipeline",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"build_branches": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"build_pull_requests": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"build_tags": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"publish_commit_status": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"publish_commit_status_per_step": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"pull_request_branch_filter_configuration": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"pull_request_branch_filter_enabled": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"skip_pull_request_builds_for_existing_commits": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"trigger_mode": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringInSlice([]string{"code", "deployment", "fork", "none"}, false),
+				},
+			},
+		},
+	}
+}
+
+// Identify the kind of repository provider, or an empty string for providers without settings.
+func repositoryProviderKind(id string) string {
+	if alias, ok := repositoryProviderAliases[id]; ok {
+		return alias
+	}
+	if _, ok := repositoryProviderSettings[id]; ok {
+		return id
+	}
+	return ""
+}
+
+// Guess the kind of repository provider from the host of a repository URL, as
+// Buildkite does when a pipeline is created, or an empty string when it is not known.
+func repositoryProviderFromURL(repository string) string {
+	for _, label := range strings.Split(strings.ToLower(repositoryHost(repository)), ".") {
+		if _, ok := repositoryProviderSettings[label]; ok {
+			return label
+		}
+	}
+	return ""
+}
+
+// Extract the host of a repository URL or of an scp-style "user@host:path" location.
+func repositoryHost(repository string) string {
+	if strings.Contains(repository, "://") {
+		location, err := url.Parse(repository)
+		if err != nil {
+			return ""
+		}
+		return location.Hostname()
+	}
+
+	i := strings.Index(repository, ":")
+	if i < 0 {
+		return ""
+	}
+	host := repository[:i]
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	return host
+}
+
+// Convert the REST API provider settings to the Terraform structure,
+// keeping only the settings the provider supports.
+func (source *PipelineProvider) convert() []interface{} {
+	supported := repositoryProviderSettings[repositoryProviderKind(source.ID)]
+	if len(supported) == 0 {
+		return []interface{}{}
+	}
+
+	settings := map[string]interface{}{}
+	for _, key := range supported {
+		if value, ok := source.Settings[key]; ok && value != nil {
+			settings[key] = value
+		}
+	}
+	return []interface{}{settings}
+}
+
+// Collect the configured provider settings supported by the provider into a
+// REST API body, filling in unset ones from the settings of a source pipeline;
+// settings left for Buildkite to decide are not sent.
+func repositoryProviderSettingsInput(d *schema.ResourceData, kind string, source map[string]interface{}) map[string]interface{} {
+	input := map[string]interface{}{}
+	for _, key := range repositoryProviderSettings[kind] {
+		if value, ok := d.GetOkExists("provider_settings.0." + key); ok {
+			input[key] = value
+		} else if value, ok := source[key]; ok && value != nil {
+			input[key] = value
+		}
+	}
+	return input
+}
+
+// Reject provider settings at plan time that the repository provider of the pipeline does not support.
+func validateRepositoryProviderSettings(d *schema.ResourceDiff) error {
+	kind := repositoryProviderFromURL(d.Get("repository").(string))
+	if kind == "" {
+		return nil
+	}
+	supported := map[string]bool{}
+	for _, key := range repositoryProviderSettings[kind] {
+		supported[key] = true
+	}
+
+	var unsupported []string
+	for key := range schemaRepositoryProviderSettings().Elem.(*schema.Resource).Schema {
+		if supported[key] {
+			continue
+		}
+		// Existing pipelines may still hold the settings of a previous provider
+		// in state, so there only settings the configuration changes count
+		setting := "provider_settings.0." + key
+		if _, ok := d.GetOkExists(setting); ok && (d.Id() == "" || d.HasChange(setting)) {
+			unsupported = append(unsupported, key)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return fmt.Errorf("provider_settings: %s not supported by %s repositories", strings.Join(unsupported, ", "), kind)
+	}
+	return nil
+}
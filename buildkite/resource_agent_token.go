@@ -0,0 +1,139 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceAgentToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAgentTokenCreate,
+		Read:   resourceAgentTokenRead,
+		Delete: resourceAgentTokenDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAgentTokenImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+				ForceNew: true,
+			},
+			"keepers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that replace the token when changed, to rotate it",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Token value used by agents to register, only known when created",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAgentTokenCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	token, value, err := client.createAgentToken(map[string]interface{}{
+		"description":    d.Get("description").(string),
+		"organizationID": organizationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(token.ID)
+	d.Set("organization_slug", slug)
+	d.Set("token", value)
+	return resourceAgentTokenRead(d, m)
+}
+
+func resourceAgentTokenRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	token, err := client.readAgentToken(d.Id())
+	if err == nil && token.RevokedAt != "" {
+		// A revoked token can no longer be used, so plan to replace it
+		err = errNotFound
+	}
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite agent token (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"created_at":  token.CreatedAt,
+		"description": token.Description,
+		"uuid":        token.UUID,
+	})
+}
+
+func resourceAgentTokenDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	err := client.revokeAgentToken(d.Id(), "Revoked by Terraform")
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import an agent token by an "organization-slug/token-uuid" identifier; the
+// token value itself cannot be read back.
+func resourceAgentTokenImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/token-uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := client.readAgentTokens(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.UUID == parts[1] {
+			d.SetId(token.ID)
+			d.Set("organization_slug", parts[0])
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no agent token found with ID (%s)", d.Id())
+}
@@ -0,0 +1,263 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Build states in which a build may still change.
+var buildPendingStates = []string{"CANCELING", "CREATING", "FAILING", "RUNNING", "SCHEDULED"}
+
+// Build states in which a build has finished, or stopped until unblocked.
+var buildFinishedStates = []string{"BLOCKED", "CANCELED", "FAILED", "NOT_RUN", "PASSED", "SKIPPED"}
+
+// Interval between polls of the state of a build that is being waited for.
+const buildPollInterval = 10 * time.Second
+
+func resourceBuild() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildCreate,
+		Read:   resourceBuildRead,
+		Update: resourceBuildUpdate,
+		Delete: resourceBuildDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBuildImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"branch": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Branch to build, defaulting to the default branch of the pipeline",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"commit": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "HEAD",
+				ForceNew: true,
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"env": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Environment variables set for the build",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"finished_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"meta_data": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Meta-data set on the build for steps to read",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"number": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"pipeline_slug": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"started_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that trigger a new build when changed",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"wait": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Wait for the build to finish when it is created, failing unless it passes",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+// Collect the build settings from Terraform into a REST request body.
+func resourceBuildInput(d *schema.ResourceData) map[string]interface{} {
+	input := map[string]interface{}{
+		"commit": d.Get("commit").(string),
+	}
+	if value, ok := d.GetOk("branch"); ok {
+		input["branch"] = value.(string)
+	}
+	if value, ok := d.GetOk("message"); ok {
+		input["message"] = value.(string)
+	}
+	if value, ok := d.GetOk("env"); ok {
+		input["env"] = value
+	}
+	if value, ok := d.GetOk("meta_data"); ok {
+		input["meta_data"] = value
+	}
+	return input
+}
+
+func resourceBuildCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	input := resourceBuildInput(d)
+	// The REST API requires a branch, so fall back to the pipeline's default
+	if _, ok := input["branch"]; !ok {
+		pipeline, err := client.readPipelineBySlug(slug, d.Get("pipeline_slug").(string))
+		if isNotFound(err) {
+			return fmt.Errorf("no pipeline found with slug (%s)", d.Get("pipeline_slug"))
+		}
+		if err != nil {
+			return err
+		}
+		input["branch"] = pipeline.DefaultBranch
+	}
+
+	id, err := client.createBuild(slug, d.Get("pipeline_slug").(string), input)
+	if err != nil {
+		return err
+	}
+
+	// Record the build before waiting, so a failed or timed out wait does not lose track of it
+	d.SetId(id)
+	d.Set("organization_slug", slug)
+
+	if d.Get("wait").(bool) {
+		if err := resourceBuildWait(d, client); err != nil {
+			return err
+		}
+	}
+	return resourceBuildRead(d, m)
+}
+
+// Wait for a build to finish, reporting an error unless it passed.
+func resourceBuildWait(d *schema.ResourceData, client *Client) error {
+	wait := &resource.StateChangeConf{
+		Pending: buildPendingStates,
+		Target:  buildFinishedStates,
+		Refresh: func() (interface{}, string, error) {
+			build, err := client.readBuild(d.Id())
+			if err != nil {
+				return nil, "", err
+			}
+			return build, build.State, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		PollInterval: buildPollInterval,
+	}
+
+	result, err := wait.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for Buildkite build (%s) to finish: %s", d.Id(), err)
+	}
+	if build := result.(*Build); build.State != "PASSED" {
+		return fmt.Errorf("build %d finished as %s: %s", build.Number, build.State, build.URL)
+	}
+	return nil
+}
+
+func resourceBuildRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	build, err := client.readBuild(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite build (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"branch":        build.Branch,
+		"created_at":    build.CreatedAt,
+		"finished_at":   build.FinishedAt,
+		"message":       build.Message,
+		"number":        build.Number,
+		"pipeline_slug": build.Pipeline.Slug,
+		"started_at":    build.StartedAt,
+		"state":         build.State,
+		"url":           build.URL,
+		"uuid":          build.UUID,
+	})
+}
+
+// Only wait can change without a new build, and it only applies when the build is created.
+func resourceBuildUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceBuildRead(d, m)
+}
+
+func resourceBuildDelete(d *schema.ResourceData, m interface{}) error {
+	// Builds cannot be deleted in Buildkite, so the build is only removed from the state
+	return nil
+}
+
+// Import a build by an "organization-slug/pipeline-slug/build-number" identifier.
+func resourceBuildImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/pipeline-slug/build-number")
+	if err != nil {
+		return nil, err
+	}
+
+	build, err := client.readBuildBySlug(parts[0], parts[1], parts[2])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no build found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(build.ID)
+	d.Set("organization_slug", parts[0])
+	return []*schema.ResourceData{d}, nil
+}
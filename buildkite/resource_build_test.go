@@ -0,0 +1,49 @@
+package buildkite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestResourceBuildInput(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		input  map[string]interface{}
+	}{
+		{
+			name:   "defaults leave the branch to the pipeline",
+			config: map[string]interface{}{},
+			input:  map[string]interface{}{"commit": "HEAD"},
+		},
+		{
+			name: "all settings",
+			config: map[string]interface{}{
+				"branch":    "release",
+				"commit":    "abc123",
+				"env":       map[string]interface{}{"DEPLOY": "1"},
+				"message":   "Release build",
+				"meta_data": map[string]interface{}{"version": "1.2"},
+			},
+			input: map[string]interface{}{
+				"branch":    "release",
+				"commit":    "abc123",
+				"env":       map[string]interface{}{"DEPLOY": "1"},
+				"message":   "Release build",
+				"meta_data": map[string]interface{}{"version": "1.2"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.config["pipeline_slug"] = "app"
+			d := schema.TestResourceDataRaw(t, resourceBuild().Schema, c.config)
+			if input := resourceBuildInput(d); !reflect.DeepEqual(input, c.input) {
+				t.Errorf("expected %v, got %v", c.input, input)
+			}
+		})
+	}
+}
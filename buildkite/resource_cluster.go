@@ -0,0 +1,166 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterCreate,
+		Read:   resourceClusterRead,
+		Update: resourceClusterUpdate,
+		Delete: resourceClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"color": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Hex color code shown for the cluster",
+				Optional:    true,
+				Default:     "",
+			},
+			"default_queue_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"emoji": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Collect the cluster settings from Terraform into a GraphQL input object.
+func resourceClusterInput(d *schema.ResourceData, organizationID string) map[string]interface{} {
+	return map[string]interface{}{
+		"color":          d.Get("color").(string),
+		"description":    d.Get("description").(string),
+		"emoji":          d.Get("emoji").(string),
+		"name":           d.Get("name").(string),
+		"organizationId": organizationID,
+	}
+}
+
+func resourceClusterCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := client.createCluster(resourceClusterInput(d, organizationID))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(cluster.ID)
+	d.Set("organization_slug", slug)
+	return resourceClusterRead(d, m)
+}
+
+func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	cluster, err := client.readCluster(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite cluster (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"color":            cluster.Color,
+		"default_queue_id": cluster.DefaultQueue.ID,
+		"description":      cluster.Description,
+		"emoji":            cluster.Emoji,
+		"name":             cluster.Name,
+		"uuid":             cluster.UUID,
+	})
+}
+
+func resourceClusterUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	organizationID, err := client.readOrganizationID(d.Get("organization_slug").(string))
+	if err != nil {
+		return err
+	}
+
+	input := resourceClusterInput(d, organizationID)
+	input["id"] = d.Id()
+
+	if _, err := client.updateCluster(input); err != nil {
+		return err
+	}
+	return resourceClusterRead(d, m)
+}
+
+func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	organizationID, err := client.readOrganizationID(d.Get("organization_slug").(string))
+	if err != nil {
+		return err
+	}
+
+	err = client.deleteCluster(organizationID, d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a cluster by an "organization-slug/cluster-uuid" identifier.
+func resourceClusterImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/cluster-uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := client.readClusterByUUID(parts[0], parts[1])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no cluster found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(cluster.ID)
+	d.Set("organization_slug", parts[0])
+	return []*schema.ResourceData{d}, nil
+}
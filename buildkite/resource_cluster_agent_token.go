@@ -0,0 +1,166 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceClusterAgentToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterAgentTokenCreate,
+		Read:   resourceClusterAgentTokenRead,
+		Update: resourceClusterAgentTokenUpdate,
+		Delete: resourceClusterAgentTokenDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterAgentTokenImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the cluster",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"keepers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that replace the token when changed, to rotate it",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Token value used by agents to register with the cluster, only known when created",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceClusterAgentTokenCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	token, value, err := client.createClusterAgentToken(map[string]interface{}{
+		"clusterId":      d.Get("cluster_id").(string),
+		"description":    d.Get("description").(string),
+		"organizationId": organizationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(token.ID)
+	d.Set("organization_slug", slug)
+	d.Set("token", value)
+	return resourceClusterAgentTokenRead(d, m)
+}
+
+func resourceClusterAgentTokenRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	token, err := client.readClusterAgentToken(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite cluster agent token (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"cluster_id":  token.Cluster.ID,
+		"created_at":  token.CreatedAt,
+		"description": token.Description,
+		"uuid":        token.UUID,
+	})
+}
+
+func resourceClusterAgentTokenUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	organizationID, err := client.readOrganizationID(d.Get("organization_slug").(string))
+	if err != nil {
+		return err
+	}
+
+	_, err = client.updateClusterAgentToken(map[string]interface{}{
+		"description":    d.Get("description").(string),
+		"id":             d.Id(),
+		"organizationId": organizationID,
+	})
+	if err != nil {
+		return err
+	}
+	return resourceClusterAgentTokenRead(d, m)
+}
+
+func resourceClusterAgentTokenDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	organizationID, err := client.readOrganizationID(d.Get("organization_slug").(string))
+	if err != nil {
+		return err
+	}
+
+	err = client.revokeClusterAgentToken(organizationID, d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a cluster agent token by an "organization-slug/cluster-uuid/token-uuid"
+// identifier; the token value itself cannot be read back.
+func resourceClusterAgentTokenImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/cluster-uuid/token-uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := client.readClusterAgentTokens(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.UUID == parts[2] {
+			d.SetId(token.ID)
+			d.Set("organization_slug", parts[0])
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no cluster agent token found with ID (%s)", d.Id())
+}
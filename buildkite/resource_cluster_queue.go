@@ -0,0 +1,155 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceClusterQueue() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterQueueCreate,
+		Read:   resourceClusterQueueRead,
+		Update: resourceClusterQueueUpdate,
+		Delete: resourceClusterQueueDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterQueueImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the cluster",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"key": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Queue name that agents and steps target",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceClusterQueueCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	queue, err := client.createClusterQueue(map[string]interface{}{
+		"clusterId":      d.Get("cluster_id").(string),
+		"description":    d.Get("description").(string),
+		"key":            d.Get("key").(string),
+		"organizationId": organizationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(queue.ID)
+	d.Set("organization_slug", slug)
+	return resourceClusterQueueRead(d, m)
+}
+
+func resourceClusterQueueRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	queue, err := client.readClusterQueue(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite cluster queue (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"cluster_id":  queue.Cluster.ID,
+		"description": queue.Description,
+		"key":         queue.Key,
+		"uuid":        queue.UUID,
+	})
+}
+
+func resourceClusterQueueUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	organizationID, err := client.readOrganizationID(d.Get("organization_slug").(string))
+	if err != nil {
+		return err
+	}
+
+	_, err = client.updateClusterQueue(map[string]interface{}{
+		"description":    d.Get("description").(string),
+		"id":             d.Id(),
+		"organizationId": organizationID,
+	})
+	if err != nil {
+		return err
+	}
+	return resourceClusterQueueRead(d, m)
+}
+
+func resourceClusterQueueDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	organizationID, err := client.readOrganizationID(d.Get("organization_slug").(string))
+	if err != nil {
+		return err
+	}
+
+	err = client.deleteClusterQueue(organizationID, d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a cluster queue by an "organization-slug/cluster-uuid/queue-key" identifier.
+func resourceClusterQueueImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/cluster-uuid/queue-key")
+	if err != nil {
+		return nil, err
+	}
+
+	queues, err := client.readClusterQueues(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, queue := range queues {
+		if queue.Key == parts[2] {
+			d.SetId(queue.ID)
+			d.Set("organization_slug", parts[0])
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no cluster queue found with ID (%s)", d.Id())
+}
@@ -0,0 +1,209 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceOrganizationInvitation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceOrganizationInvitationCreate,
+		Read:   resourceOrganizationInvitationRead,
+		Delete: resourceOrganizationInvitationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceOrganizationInvitationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"accepted_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"accepted_by_user_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the user who accepted the invitation",
+				Computed:    true,
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "Email address to send the invitation to",
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDifference,
+			},
+			"expires_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MEMBER",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ADMIN", "MEMBER"}, false),
+			},
+			"state": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "One of PENDING, ACCEPTED, EXPIRED or REVOKED",
+				Computed:    true,
+			},
+			"team": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Teams the user joins on accepting the invitation",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "MEMBER",
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"MAINTAINER", "MEMBER"}, false),
+						},
+						"team_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "GraphQL identifier of the team",
+							Required:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceOrganizationInvitationCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	teams := []interface{}{}
+	for _, item := range d.Get("team").(*schema.Set).List() {
+		team := item.(map[string]interface{})
+		teams = append(teams, map[string]interface{}{
+			"id":   team["team_id"].(string),
+			"role": enum(team["role"].(string)),
+		})
+	}
+
+	invitation, err := client.createOrganizationInvitation(map[string]interface{}{
+		"emails":         []string{d.Get("email").(string)},
+		"organizationID": organizationID,
+		"role":           enum(d.Get("role").(string)),
+		"teams":          teams,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(invitation.ID)
+	d.Set("organization_slug", slug)
+	return resourceOrganizationInvitationRead(d, m)
+}
+
+// Read an invitation, whose state moves on from PENDING as the user accepts
+// it or it expires; those invitations are kept so they are not sent again.
+func resourceOrganizationInvitationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	invitation, err := client.readOrganizationInvitation(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite organization invitation (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	invitationTeams, err := client.readOrganizationInvitationTeams(invitation.ID)
+	if err != nil {
+		return err
+	}
+	teams := []interface{}{}
+	for _, team := range invitationTeams {
+		teams = append(teams, map[string]interface{}{
+			"role":    team.Role,
+			"team_id": team.Team.ID,
+		})
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"accepted_at":         invitation.AcceptedAt,
+		"accepted_by_user_id": invitation.AcceptedBy.ID,
+		"created_at":          invitation.CreatedAt,
+		"email":               invitation.Email,
+		"expires_at":          invitation.ExpiresAt,
+		"role":                invitation.Role,
+		"state":               invitation.State,
+		"team":                teams,
+		"uuid":                invitation.UUID,
+	})
+}
+
+// Revoke a pending invitation; accepted invitations are managed through
+// buildkite_organization_member instead.
+func resourceOrganizationInvitationDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	invitation, err := client.readOrganizationInvitation(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if invitation.State != "PENDING" {
+		return nil
+	}
+	return client.revokeOrganizationInvitation(d.Id())
+}
+
+// Import an organization invitation by an "organization-slug/invitation-uuid" identifier.
+func resourceOrganizationInvitationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/invitation-uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := client.readOrganizationInvitations(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invitation := range invitations {
+		if invitation.UUID == parts[1] {
+			d.SetId(invitation.ID)
+			d.Set("organization_slug", parts[0])
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no organization invitation found with ID (%s)", d.Id())
+}
@@ -0,0 +1,145 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceOrganizationMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceOrganizationMemberCreate,
+		Read:   resourceOrganizationMemberRead,
+		Update: resourceOrganizationMemberUpdate,
+		Delete: resourceOrganizationMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceOrganizationMemberImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "Email of an existing member of the organization",
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDifference,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MEMBER",
+				ValidateFunc: validation.StringInSlice([]string{"ADMIN", "MEMBER"}, false),
+			},
+			"user_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Members join an organization by invitation, so creating the resource takes
+// over an existing member and applies the configured role.
+func resourceOrganizationMemberCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	email := d.Get("email").(string)
+
+	member, err := client.readMemberByEmail(slug, email)
+	if isNotFound(err) {
+		return fmt.Errorf("no member of organization %s found with email %q", slug, email)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(member.ID)
+	d.Set("organization_slug", slug)
+
+	if role := d.Get("role").(string); role != member.Role {
+		if _, err := client.updateMember(member.ID, role); err != nil {
+			return err
+		}
+	}
+	return resourceOrganizationMemberRead(d, m)
+}
+
+func resourceOrganizationMemberRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	member, err := client.readMember(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite organization member (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceState(d, resourceOrganizationMember(), member.convert(DataSourceFullEntity))
+}
+
+func resourceOrganizationMemberUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	if _, err := client.updateMember(d.Id(), d.Get("role").(string)); err != nil {
+		return err
+	}
+	return resourceOrganizationMemberRead(d, m)
+}
+
+func resourceOrganizationMemberDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	err := client.deleteMember(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import an organization member by an "organization-slug/user-email" identifier.
+func resourceOrganizationMemberImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/user-email")
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := client.readMemberByEmail(parts[0], parts[1])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no organization member found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(member.ID)
+	d.Set("organization_slug", parts[0])
+	return []*schema.ResourceData{d}, nil
+}
@@ -1,37 +1,559 @@
 package buildkite
 
 import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
+// Steps uploaded from the repository when a pipeline does not define its own.
+const defaultPipelineSteps = "steps:\n  - command: \"buildkite-agent pipeline upload\"\n    label: \":pipeline:\"\n"
+
+// Slugs accepted by Buildkite in pipeline URLs.
+var pipelineSlugFormat = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Runs of characters that Buildkite replaces with a dash when deriving a slug.
+var pipelineSlugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
 func resourcePipeline() *schema.Resource {
 	return &schema.Resource{
 		Create: resourcePipelineCreate,
 		Read:   resourcePipelineRead,
 		Update: resourcePipelineUpdate,
 		Delete: resourcePipelineDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePipelineImport,
+		},
+		CustomizeDiff: customizePipelineDiff,
 
 		Schema: map[string]*schema.Schema{
-			"address": &schema.Schema{
+			"archive_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Archive the pipeline on destroy instead of deleting it and its build history",
+				Optional:    true,
+				Default:     false,
+			},
+			"archived": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"cancel_intermediate_builds": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"cancel_intermediate_builds_branch_filter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "GraphQL identifier of the cluster whose agents run the pipeline",
+				Optional:         true,
+				DiffSuppressFunc: suppressCopiedPipelineSetting,
+			},
+			"copied_settings": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Settings copied from the source pipeline at creation that are kept while left unset",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"default_branch": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"pipeline_template_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "GraphQL identifier of the pipeline template the pipeline uses",
+				Optional:         true,
+				DiffSuppressFunc: suppressCopiedPipelineSetting,
+			},
+			"provider_settings": schemaRepositoryProviderSettings(),
+			"repository": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Repository URL, required unless copied from a source pipeline",
+				Optional:    true,
+				Computed:    true,
+			},
+			"skip_intermediate_builds": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"skip_intermediate_builds_branch_filter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "URL slug of the pipeline, derived from the name unless given",
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: validation.StringMatch(pipelineSlugFormat,
+					"must contain only lowercase letters, numbers and dashes"),
+			},
+			"source_pipeline_slug": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Slug of a pipeline in the same organization to copy settings, provider settings and steps from",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(pipelineSlugFormat, "must be a pipeline slug"),
+			},
+			"steps": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "Pipeline steps YAML, checked at plan time against the step types and attributes known to the provider rather than the full pipeline schema",
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressEquivalentPipelineSteps,
+				StateFunc:        normalizePipelineSteps,
+				ValidateFunc:     validatePipelineSteps,
+			},
+			"teams": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Teams granted access to the pipeline; grants to other teams are left alone",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_level": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "MANAGE_BUILD_AND_READ",
+							ValidateFunc: validation.StringInSlice(teamPipelineAccessLevels, false),
+						},
+						"team_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "GraphQL identifier of the team",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"visibility": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PRIVATE", "PUBLIC"}, false),
+			},
+			"webhook_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// Collect the pipeline settings from Terraform into a GraphQL input object;
+// settings left unset are copied from a source pipeline or left to Buildkite.
+func resourcePipelineInput(d *schema.ResourceData) map[string]interface{} {
+	input := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+	if value, ok := d.GetOkExists("cancel_intermediate_builds"); ok {
+		input["cancelIntermediateBuilds"] = value.(bool)
+	}
+	if value, ok := d.GetOkExists("cancel_intermediate_builds_branch_filter"); ok {
+		input["cancelIntermediateBuildsBranchFilter"] = value.(string)
+	}
+	if value, ok := d.GetOk("cluster_id"); ok {
+		input["clusterId"] = value.(string)
+	} else if d.HasChange("cluster_id") {
+		// Moving the pipeline out of its cluster requires an explicit null
+		input["clusterId"] = nil
+	}
+	if value, ok := d.GetOk("default_branch"); ok {
+		input["defaultBranch"] = value.(string)
+	}
+	if value, ok := d.GetOkExists("description"); ok {
+		input["description"] = value.(string)
+	}
+	if d.HasChange("pipeline_template_id") {
+		// Removing the template is only possible by sending an explicit null
+		if value, ok := d.GetOk("pipeline_template_id"); ok {
+			input["pipelineTemplateId"] = value.(string)
+		} else {
+			input["pipelineTemplateId"] = nil
+		}
+	}
+	if value, ok := d.GetOk("repository"); ok {
+		input["repository"] = map[string]interface{}{"url": value.(string)}
+	}
+	if value, ok := d.GetOkExists("skip_intermediate_builds"); ok {
+		input["skipIntermediateBuilds"] = value.(bool)
+	}
+	if value, ok := d.GetOkExists("skip_intermediate_builds_branch_filter"); ok {
+		input["skipIntermediateBuildsBranchFilter"] = value.(string)
+	}
+	if value, ok := d.GetOk("slug"); ok && d.HasChanges("name", "slug") {
+		input["slug"] = value.(string)
+	}
+	if value, ok := d.GetOk("steps"); ok {
+		input["steps"] = map[string]interface{}{"yaml": value.(string)}
+	}
+	if value, ok := d.GetOk("visibility"); ok {
+		input["visibility"] = enum(value.(string))
+	}
+	return input
+}
+
+// Copy the settings of a source pipeline into a GraphQL input object; a
+// pipeline using a template takes its steps from the template instead.
+func resourcePipelineSourceInput(source *Pipeline) map[string]interface{} {
+	input := map[string]interface{}{
+		"cancelIntermediateBuilds":             source.CancelIntermediateBuilds,
+		"cancelIntermediateBuildsBranchFilter": source.CancelIntermediateBuildsBranchFilter,
+		"defaultBranch":                        source.DefaultBranch,
+		"description":                          source.Description,
+		"repository":                           map[string]interface{}{"url": source.Repository.URL},
+		"skipIntermediateBuilds":               source.SkipIntermediateBuilds,
+		"skipIntermediateBuildsBranchFilter":   source.SkipIntermediateBuildsBranchFilter,
+	}
+	if source.Cluster.ID != "" {
+		input["clusterId"] = source.Cluster.ID
+	}
+	if source.Visibility != "" {
+		input["visibility"] = enum(source.Visibility)
+	}
+	if source.PipelineTemplate.ID != "" {
+		input["pipelineTemplateId"] = source.PipelineTemplate.ID
+	} else {
+		input["steps"] = map[string]interface{}{"yaml": source.Steps.YAML}
+	}
+	return input
+}
+
 func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	input := map[string]interface{}{}
+	copied := map[string]interface{}{}
+	var sourceSettings map[string]interface{}
+	if sourceSlug, ok := d.GetOk("source_pipeline_slug"); ok {
+		source, err := client.readPipelineBySlug(slug, sourceSlug.(string))
+		if isNotFound(err) {
+			return fmt.Errorf("no source pipeline found with slug (%s)", sourceSlug)
+		}
+		if err != nil {
+			return err
+		}
+		provider, err := client.readPipelineProvider(slug, source.Slug)
+		if err != nil {
+			return err
+		}
+		input = resourcePipelineSourceInput(source)
+		sourceSettings = provider.Settings
+		if _, ok := d.GetOk("cluster_id"); !ok && source.Cluster.ID != "" {
+			copied["cluster_id"] = source.Cluster.ID
+		}
+		if _, ok := d.GetOk("pipeline_template_id"); !ok && source.PipelineTemplate.ID != "" {
+			copied["pipeline_template_id"] = source.PipelineTemplate.ID
+		}
+	}
+	for key, value := range resourcePipelineInput(d) {
+		input[key] = value
+	}
+
+	if _, ok := input["repository"]; !ok {
+		return fmt.Errorf("repository is required unless source_pipeline_slug is given")
+	}
+	if _, ok := input["pipelineTemplateId"]; !ok {
+		if _, ok := input["steps"]; !ok {
+			input["steps"] = map[string]interface{}{"yaml": defaultPipelineSteps}
+		}
+	}
+	if teams := resourcePipelineTeams(d.Get("teams").(*schema.Set)); len(teams) > 0 {
+		list := []interface{}{}
+		for _, teamID := range sortedKeys(teams) {
+			list = append(list, map[string]interface{}{"accessLevel": enum(teams[teamID]), "id": teamID})
+		}
+		input["teams"] = list
+	}
+	input["organizationId"] = organizationID
+
+	pipeline, err := client.createPipeline(input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(pipeline.ID)
+	d.Set("copied_settings", copied)
+	d.Set("organization_slug", slug)
+
+	if err := resourcePipelineUpdateProvider(d, client, pipeline.Slug, sourceSettings); err != nil {
+		return err
+	}
+
+	if d.Get("archived").(bool) {
+		if _, err := client.archivePipeline(pipeline.ID); err != nil {
+			return err
+		}
+	}
 	return resourcePipelineRead(d, m)
 }
 
 func resourcePipelineRead(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	pipeline, err := client.readPipeline(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite pipeline (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	provider, err := client.readPipelineProvider(d.Get("organization_slug").(string), pipeline.Slug)
+	if err != nil {
+		return err
+	}
+
+	grants, err := pipelineTeamGrants(client, pipeline.ID).read()
+	if err != nil {
+		return err
+	}
+	teams := resourcePipelineTeamsFromGrants(d, grants)
+
+	values := pipeline.convert(DataSourceFullEntity)
+	values["provider_settings"] = provider.convert()
+	values["teams"] = teams
+	values["steps"] = normalizePipelineSteps(pipeline.Steps.YAML)
+	return setResourceState(d, resourcePipeline(), values)
+}
+
+// Pipeline settings that are changed through the pipelineUpdate mutation.
+var pipelineUpdateKeys = []string{
+	"cancel_intermediate_builds",
+	"cancel_intermediate_builds_branch_filter",
+	"cluster_id",
+	"default_branch",
+	"description",
+	"name",
+	"pipeline_template_id",
+	"repository",
+	"skip_intermediate_builds",
+	"skip_intermediate_builds_branch_filter",
+	"slug",
+	"steps",
+	"visibility",
+}
+
+// Decide whether an update must unarchive the pipeline before its other changes
+// and archive it after them; archived pipelines cannot be changed, so one that
+// stays archived is restored for the changes and archived again afterwards.
+func resourcePipelineArchiveChanges(d *schema.ResourceData) (unarchive bool, archive bool) {
+	wasArchived, archived := d.GetChange("archived")
+	changed := d.HasChanges(pipelineUpdateKeys...) || d.HasChanges("provider_settings", "teams")
+	unarchive = wasArchived.(bool) && (changed || !archived.(bool))
+	archive = archived.(bool) && (changed || !wasArchived.(bool))
+	return
 }
 
 func resourcePipelineUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	unarchive, archive := resourcePipelineArchiveChanges(d)
+	if unarchive {
+		if _, err := client.unarchivePipeline(d.Id()); err != nil {
+			return err
+		}
+	}
+
+	slug := d.Get("slug").(string)
+	if d.HasChanges(pipelineUpdateKeys...) {
+		input := resourcePipelineInput(d)
+		input["id"] = d.Id()
+
+		pipeline, err := client.updatePipeline(input)
+		if err != nil {
+			return err
+		}
+		slug = pipeline.Slug
+	}
+
+	if d.HasChange("provider_settings") {
+		if err := resourcePipelineUpdateProvider(d, client, slug, nil); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("teams") {
+		if err := resourcePipelineUpdateTeams(d, client); err != nil {
+			return err
+		}
+	}
+
+	if archive {
+		if _, err := client.archivePipeline(d.Id()); err != nil {
+			return err
+		}
+	}
 	return resourcePipelineRead(d, m)
 }
 
+// Apply the configured repository provider settings through the REST API,
+// which is the only API that manages them, on top of any copied settings.
+func resourcePipelineUpdateProvider(d *schema.ResourceData, client *Client, slug string, source map[string]interface{}) error {
+	organization := d.Get("organization_slug").(string)
+
+	provider, err := client.readPipelineProvider(organization, slug)
+	if err != nil {
+		return err
+	}
+
+	input := repositoryProviderSettingsInput(d, repositoryProviderKind(provider.ID), source)
+	if len(input) == 0 {
+		return nil
+	}
+	return client.updatePipelineProvider(organization, slug, input)
+}
+
+// Collect the access level granted to each team in a teams block, by team ID.
+func resourcePipelineTeams(set *schema.Set) map[string]string {
+	teams := map[string]string{}
+	for _, item := range set.List() {
+		team := item.(map[string]interface{})
+		teams[team["team_id"].(string)] = team["access_level"].(string)
+	}
+	return teams
+}
+
+// Convert the team grants of a pipeline to its teams block, keeping only grants
+// to teams in the block, since grants made elsewhere, such as by
+// buildkite_team_pipelines, are not managed here.
+func resourcePipelineTeamsFromGrants(d *schema.ResourceData, grants []grant) []interface{} {
+	configured := resourcePipelineTeams(d.Get("teams").(*schema.Set))
+	return grantSet(grants, "access_level", "team_id", func(teamID string) bool {
+		_, ok := configured[teamID]
+		return ok
+	})
+}
+
+// Collect the access levels a change to the teams block asks for, and which
+// grants may be revoked: only those removed from the block, so grants made
+// elsewhere are kept.
+func resourcePipelineTeamChanges(d *schema.ResourceData) (accessLevels map[string]string, revoke func(teamID string) bool) {
+	old, new := d.GetChange("teams")
+	oldTeams := resourcePipelineTeams(old.(*schema.Set))
+
+	return resourcePipelineTeams(new.(*schema.Set)), func(teamID string) bool {
+		_, ok := oldTeams[teamID]
+		return ok
+	}
+}
+
+// Reconcile the team grants of a pipeline with its teams block.
+func resourcePipelineUpdateTeams(d *schema.ResourceData, client *Client) error {
+	accessLevels, revoke := resourcePipelineTeamChanges(d)
+	return pipelineTeamGrants(client, d.Id()).apply(accessLevels, revoke)
+}
+
+// Suppress the removal of a setting that still holds the value copied from a
+// source pipeline at creation, so copied values are kept while left unset but
+// any value configured since can be removed again.
+func suppressCopiedPipelineSetting(k, old, new string, d *schema.ResourceData) bool {
+	copied, _ := d.Get("copied_settings").(map[string]interface{})[k].(string)
+	return new == "" && old != "" && old == copied
+}
+
+// Derive the slug Buildkite gives a pipeline with the given name.
+func pipelineSlugFromName(name string) string {
+	return strings.Trim(pipelineSlugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// Check the pipeline configuration at plan time beyond what the schema can
+// express, and show that a rename may also change the derived slug.
+func customizePipelineDiff(d *schema.ResourceDiff, m interface{}) error {
+	// The diff cannot tell a configured slug from a computed one, so a slug
+	// still derived from the previous name is taken to be left to Buildkite;
+	// any other slug is sent again with the new name and kept
+	if d.Id() != "" && d.HasChange("name") && !d.HasChange("slug") {
+		oldName, _ := d.GetChange("name")
+		if d.Get("slug").(string) == pipelineSlugFromName(oldName.(string)) {
+			for _, key := range []string{"slug", "url"} {
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return validateRepositoryProviderSettings(d)
+}
+
 func resourcePipelineDelete(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	var err error
+	switch {
+	case !d.Get("archive_on_destroy").(bool):
+		err = client.deletePipeline(d.Id())
+	case !d.Get("archived").(bool):
+		_, err = client.archivePipeline(d.Id())
+	}
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a pipeline by an "organization-slug/pipeline-slug" identifier.
+func resourcePipelineImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/pipeline-slug")
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := client.readPipelineBySlug(parts[0], parts[1])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no pipeline found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(pipeline.ID)
+	d.Set("archive_on_destroy", false)
+	d.Set("organization_slug", parts[0])
+	return []*schema.ResourceData{d}, nil
 }
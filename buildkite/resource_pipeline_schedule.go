@@ -1,6 +1,12 @@
 package buildkite
 
 import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -10,28 +16,200 @@ func resourcePipelineSchedule() *schema.Resource {
 		Read:   resourcePipelineScheduleRead,
 		Update: resourcePipelineScheduleUpdate,
 		Delete: resourcePipelineScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePipelineScheduleImport,
+		},
+		CustomizeDiff: customizePipelineScheduleDiff,
 
 		Schema: map[string]*schema.Schema{
-			"address": &schema.Schema{
+			"branch": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"commit": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "HEAD",
+			},
+			"cronline": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Cron fields or a predefined schedule such as @daily, optionally followed by a time zone",
+				Required:     true,
+				ValidateFunc: validateCronline,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"env": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"label": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"message": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"next_build_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"next_runs": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Upcoming fire times of the schedule, computed locally",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"pipeline_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the scheduled pipeline",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// Collect the schedule settings from Terraform into a GraphQL input object.
+func resourcePipelineScheduleInput(d *schema.ResourceData) map[string]interface{} {
+	var env []string
+	for key, value := range d.Get("env").(map[string]interface{}) {
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(env)
+
+	input := map[string]interface{}{
+		"commit":   d.Get("commit").(string),
+		"cronline": d.Get("cronline").(string),
+		"enabled":  d.Get("enabled").(bool),
+		"env":      strings.Join(env, "\n"),
+		"label":    d.Get("label").(string),
+		"message":  d.Get("message").(string),
+	}
+	if value, ok := d.GetOk("branch"); ok {
+		input["branch"] = value.(string)
+	}
+	return input
+}
+
 func resourcePipelineScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	input := resourcePipelineScheduleInput(d)
+	input["pipelineID"] = d.Get("pipeline_id").(string)
+
+	schedule, err := client.createPipelineSchedule(input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(schedule.ID)
 	return resourcePipelineScheduleRead(d, m)
 }
 
 func resourcePipelineScheduleRead(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	schedule, err := client.readPipelineSchedule(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite pipeline schedule (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	env := make(map[string]string)
+	for _, e := range schedule.Env {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) == 2 {
+			env[parts[0]] = parts[1]
+		} else {
+			env[parts[0]] = ""
+		}
+	}
+
+	// The preview planned for a new cronline or enabled state is kept, and is
+	// otherwise anchored to the next build so it is stable across refreshes
+	nextRuns := []string{}
+	planned, _ := d.Get("next_runs").([]interface{})
+	switch {
+	case !schedule.Enabled:
+	case d.HasChanges("cronline", "enabled") && len(planned) > 0:
+		for _, run := range planned {
+			nextRuns = append(nextRuns, run.(string))
+		}
+	default:
+		nextRuns = cronlineNextRunsFrom(schedule.Cronline, schedule.NextBuildAt, time.Now(), pipelineScheduleNextRuns)
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"branch":        schedule.Branch,
+		"commit":        schedule.Commit,
+		"cronline":      schedule.Cronline,
+		"enabled":       schedule.Enabled,
+		"env":           env,
+		"label":         schedule.Label,
+		"message":       schedule.Message,
+		"next_build_at": schedule.NextBuildAt,
+		"next_runs":     nextRuns,
+		"pipeline_id":   schedule.Pipeline.ID,
+		"uuid":          schedule.UUID,
+	})
 }
 
 func resourcePipelineScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	input := resourcePipelineScheduleInput(d)
+	input["id"] = d.Id()
+
+	if _, err := client.updatePipelineSchedule(input); err != nil {
+		return err
+	}
 	return resourcePipelineScheduleRead(d, m)
 }
 
 func resourcePipelineScheduleDelete(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	err := client.deletePipelineSchedule(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a pipeline schedule by an "organization-slug/pipeline-slug/schedule-uuid" identifier.
+func resourcePipelineScheduleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/pipeline-slug/schedule-uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := client.readPipelineScheduleBySlug(parts[0], parts[1], parts[2])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no pipeline schedule found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(schedule.ID)
+	return []*schema.ResourceData{d}, nil
 }
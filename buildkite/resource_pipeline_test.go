@@ -0,0 +1,263 @@
+package buildkite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// Configure a pipeline with the given attributes on top of the required ones.
+func testPipelineConfig(attributes map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"name":       "My Pipeline",
+		"repository": "git@github.com:acme/app.git",
+	}
+	for key, value := range attributes {
+		config[key] = value
+	}
+	return config
+}
+
+// Create the state of a pipeline from its configuration, computing the slug
+// the way Buildkite does unless one is configured.
+func testPipelineState(t *testing.T, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	r := resourcePipeline()
+	r.Create = func(d *schema.ResourceData, m interface{}) error {
+		d.SetId("pipeline")
+		if d.Get("slug").(string) == "" {
+			d.Set("slug", pipelineSlugFromName(d.Get("name").(string)))
+		}
+		return nil
+	}
+
+	diff, err := r.Diff(nil, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := r.Apply(nil, diff, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+// Plan an update of a pipeline to a new configuration and apply it with the
+// given function in place of the API calls, returning the planned diff.
+func testPipelineUpdate(t *testing.T, state *terraform.InstanceState, config map[string]interface{}, update func(d *schema.ResourceData)) *terraform.InstanceDiff {
+	t.Helper()
+	r := resourcePipeline()
+	updated := false
+	r.Update = func(d *schema.ResourceData, m interface{}) error {
+		updated = true
+		update(d)
+		return nil
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Empty() {
+		t.Fatal("expected the configuration to change the pipeline")
+	}
+	if _, err := r.Apply(state, diff, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !updated {
+		t.Fatal("expected the pipeline to be updated")
+	}
+	return diff
+}
+
+func TestPipelineSlugFromName(t *testing.T) {
+	cases := map[string]string{
+		"agent":                   "agent",
+		"My Pipeline":             "my-pipeline",
+		"  Deploy -- Production ": "deploy-production",
+		"iOS/Android (nightly)":   "ios-android-nightly",
+		"release_v2.1":            "release-v2-1",
+	}
+
+	for name, expected := range cases {
+		if actual := pipelineSlugFromName(name); actual != expected {
+			t.Errorf("expected %q for %q, got %q", expected, name, actual)
+		}
+	}
+}
+
+func TestSuppressCopiedPipelineSetting(t *testing.T) {
+	cloned := map[string]string{
+		"copied_settings.%":          "1",
+		"copied_settings.cluster_id": "cluster-1",
+	}
+	cases := []struct {
+		name     string
+		state    map[string]string
+		key      string
+		old      string
+		new      string
+		expected bool
+	}{
+		{"copied value left unset", cloned, "cluster_id", "cluster-1", "", true},
+		{"configured after cloning then removed", cloned, "cluster_id", "cluster-2", "", false},
+		{"copied value changed", cloned, "cluster_id", "cluster-1", "cluster-2", false},
+		{"nothing copied for key", cloned, "pipeline_template_id", "template-1", "", false},
+		{"not cloned", map[string]string{}, "cluster_id", "cluster-1", "", false},
+	}
+
+	for _, c := range cases {
+		d := resourcePipeline().Data(&terraform.InstanceState{ID: "pipeline", Attributes: c.state})
+		if actual := suppressCopiedPipelineSetting(c.key, c.old, c.new, d); actual != c.expected {
+			t.Errorf("%s: expected %t, got %t", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestResourcePipelineArchiveChanges(t *testing.T) {
+	cases := []struct {
+		name      string
+		state     map[string]interface{}
+		config    map[string]interface{}
+		unarchive bool
+		update    bool
+		archive   bool
+	}{
+		{
+			name:    "archive",
+			state:   map[string]interface{}{},
+			config:  map[string]interface{}{"archived": true},
+			archive: true,
+		},
+		{
+			name:      "unarchive",
+			state:     map[string]interface{}{"archived": true},
+			config:    map[string]interface{}{},
+			unarchive: true,
+		},
+		{
+			name:      "change while archived",
+			state:     map[string]interface{}{"archived": true},
+			config:    map[string]interface{}{"archived": true, "description": "Deploys"},
+			unarchive: true,
+			update:    true,
+			archive:   true,
+		},
+		{
+			name:   "change archive_on_destroy while archived",
+			state:  map[string]interface{}{"archived": true},
+			config: map[string]interface{}{"archived": true, "archive_on_destroy": false},
+		},
+		{
+			name:   "change while not archived",
+			state:  map[string]interface{}{},
+			config: map[string]interface{}{"description": "Deploys"},
+			update: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testPipelineState(t, testPipelineConfig(c.state))
+			testPipelineUpdate(t, state, testPipelineConfig(c.config), func(d *schema.ResourceData) {
+				unarchive, archive := resourcePipelineArchiveChanges(d)
+				update := d.HasChanges(pipelineUpdateKeys...)
+				if unarchive != c.unarchive || update != c.update || archive != c.archive {
+					t.Errorf("expected unarchive %t, update %t, archive %t; got %t, %t, %t",
+						c.unarchive, c.update, c.archive, unarchive, update, archive)
+				}
+			})
+		})
+	}
+}
+
+func TestResourcePipelineSlugOnRename(t *testing.T) {
+	cases := []struct {
+		name     string
+		state    map[string]interface{}
+		config   map[string]interface{}
+		computed bool
+		slug     interface{}
+	}{
+		{
+			name:     "rename with a derived slug",
+			state:    map[string]interface{}{},
+			config:   map[string]interface{}{"name": "Web App"},
+			computed: true,
+		},
+		{
+			name:   "rename with a given slug",
+			state:  map[string]interface{}{"slug": "app"},
+			config: map[string]interface{}{"name": "Web App", "slug": "app"},
+			slug:   "app",
+		},
+		{
+			name:   "change the slug",
+			state:  map[string]interface{}{},
+			config: map[string]interface{}{"slug": "app"},
+			slug:   "app",
+		},
+		{
+			name:   "other change",
+			state:  map[string]interface{}{"slug": "app"},
+			config: map[string]interface{}{"slug": "app", "description": "Deploys"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testPipelineState(t, testPipelineConfig(c.state))
+			diff := testPipelineUpdate(t, state, testPipelineConfig(c.config), func(d *schema.ResourceData) {
+				if slug := resourcePipelineInput(d)["slug"]; slug != c.slug {
+					t.Errorf("expected slug %v to be sent, got %v", c.slug, slug)
+				}
+			})
+
+			for _, key := range []string{"slug", "url"} {
+				computed := diff.Attributes[key] != nil && diff.Attributes[key].NewComputed
+				if computed != c.computed {
+					t.Errorf("expected %s to be recomputed %t, got %t", key, c.computed, computed)
+				}
+			}
+		})
+	}
+}
+
+func TestResourcePipelineTeams(t *testing.T) {
+	team := func(teamID string, accessLevel string) map[string]interface{} {
+		return map[string]interface{}{"access_level": accessLevel, "team_id": teamID}
+	}
+	state := testPipelineState(t, testPipelineConfig(map[string]interface{}{
+		"teams": []interface{}{team("alpha", "READ_ONLY"), team("beta", "BUILD_AND_READ")},
+	}))
+
+	// Grants to teams outside the teams block are not read back
+	grants := []grant{
+		{id: "g1", level: "READ_ONLY", subject: "alpha"},
+		{id: "g2", level: "BUILD_AND_READ", subject: "beta"},
+		{id: "g3", level: "MANAGE_BUILD_AND_READ", subject: "elsewhere"},
+	}
+	teams := resourcePipelineTeamsFromGrants(resourcePipeline().Data(state), grants)
+	if len(teams) != 2 {
+		t.Errorf("expected only the configured teams to be read, got %v", teams)
+	}
+
+	// Only teams removed from the teams block may be revoked
+	config := testPipelineConfig(map[string]interface{}{
+		"teams": []interface{}{team("alpha", "MANAGE_BUILD_AND_READ"), team("gamma", "READ_ONLY")},
+	})
+	testPipelineUpdate(t, state, config, func(d *schema.ResourceData) {
+		accessLevels, revoke := resourcePipelineTeamChanges(d)
+		expected := map[string]string{"alpha": "MANAGE_BUILD_AND_READ", "gamma": "READ_ONLY"}
+		if !reflect.DeepEqual(accessLevels, expected) {
+			t.Errorf("expected access levels %v, got %v", expected, accessLevels)
+		}
+		for teamID, expected := range map[string]bool{"beta": true, "elsewhere": false} {
+			if actual := revoke(teamID); actual != expected {
+				t.Errorf("expected revoking %s to be %t, got %t", teamID, expected, actual)
+			}
+		}
+	})
+}
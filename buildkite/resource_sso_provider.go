@@ -1,7 +1,11 @@
 package buildkite
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceSsoProvider() *schema.Resource {
@@ -10,28 +14,262 @@ func resourceSsoProvider() *schema.Resource {
 		Read:   resourceSsoProviderRead,
 		Update: resourceSsoProviderUpdate,
 		Delete: resourceSsoProviderDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSsoProviderImport,
+		},
 
 		Schema: map[string]*schema.Schema{
-			"address": &schema.Schema{
+			"email_domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"email_domain_verification_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"github_app": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Settings for a GitHub app SSO provider",
+				ConflictsWith: []string{"google_gsuite", "saml"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"organization_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"google_gsuite": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Settings for a Google G Suite SSO provider",
+				ConflictsWith: []string{"github_app", "saml"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hosted_domain": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"note": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"saml": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Settings for a SAML SSO provider",
+				ConflictsWith: []string{"github_app", "google_gsuite"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"digest_method": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"idp_certificate": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"idp_certificate_fingerprint": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SHA-256 fingerprint of the identity provider certificate",
+						},
+						"idp_issuer": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"idp_metadata_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"idp_sso_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"signature_method": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sp_issuer": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sp_metadata_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sp_sso_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"session_duration_in_hours": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"GITHUB_APP", "GOOGLE_GSUITE", "SAML"}, false),
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Computed: true,
 			},
 		},
 	}
 }
 
+// Collect the SSO provider settings from Terraform into a GraphQL input object.
+func resourceSsoProviderInput(d *schema.ResourceData) map[string]interface{} {
+	input := map[string]interface{}{
+		"emailDomain":                    d.Get("email_domain").(string),
+		"emailDomainVerificationAddress": d.Get("email_domain_verification_address").(string),
+		"note":                           d.Get("note").(string),
+	}
+	if value, ok := d.GetOk("session_duration_in_hours"); ok {
+		input["sessionDurationInHours"] = value.(int)
+	}
+	if value, ok := d.GetOk("github_app.0.organization_name"); ok {
+		input["githubOrganizationName"] = value.(string)
+	}
+	if value, ok := d.GetOk("google_gsuite.0.hosted_domain"); ok {
+		input["googleHostedDomain"] = value.(string)
+	}
+	if _, ok := d.GetOk("saml.0"); ok {
+		identityProvider := map[string]interface{}{}
+		if value, ok := d.GetOk("saml.0.idp_certificate"); ok {
+			identityProvider["certificate"] = value.(string)
+		}
+		if value, ok := d.GetOk("saml.0.idp_issuer"); ok {
+			identityProvider["issuer"] = value.(string)
+		}
+		if value, ok := d.GetOk("saml.0.idp_metadata_url"); ok {
+			identityProvider["metadata"] = map[string]interface{}{"url": value.(string)}
+		}
+		if value, ok := d.GetOk("saml.0.idp_sso_url"); ok {
+			identityProvider["ssoURL"] = value.(string)
+		}
+		input["identityProvider"] = identityProvider
+	}
+	return input
+}
+
 func resourceSsoProviderCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	input := resourceSsoProviderInput(d)
+	input["organizationId"] = organizationID
+	input["type"] = enum(d.Get("type").(string))
+
+	ssoProvider, err := client.createSsoProvider(input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(ssoProvider.ID)
+	d.Set("organization_slug", slug)
 	return resourceSsoProviderRead(d, m)
 }
 
 func resourceSsoProviderRead(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	ssoProvider, err := client.readSsoProvider(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite SSO provider (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceState(d, resourceSsoProvider(), ssoProvider.convert(DataSourceFullEntity))
 }
 
 func resourceSsoProviderUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	input := resourceSsoProviderInput(d)
+	input["id"] = d.Id()
+
+	if _, err := client.updateSsoProvider(input); err != nil {
+		return err
+	}
 	return resourceSsoProviderRead(d, m)
 }
 
 func resourceSsoProviderDelete(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	err := client.deleteSsoProvider(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import an SSO provider by an "organization-slug/sso-provider-uuid" identifier.
+func resourceSsoProviderImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/sso-provider-uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	ssoProviders, err := client.readSsoProviders(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ssoProvider := range ssoProviders {
+		if ssoProvider.UUID == parts[1] {
+			d.SetId(ssoProvider.ID)
+			d.Set("organization_slug", parts[0])
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no SSO provider found with ID (%s)", d.Id())
 }
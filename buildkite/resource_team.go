@@ -1,7 +1,11 @@
 package buildkite
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceTeam() *schema.Resource {
@@ -10,28 +14,152 @@ func resourceTeam() *schema.Resource {
 		Read:   resourceTeamRead,
 		Update: resourceTeamUpdate,
 		Delete: resourceTeamDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamImport,
+		},
 
 		Schema: map[string]*schema.Schema{
-			"address": &schema.Schema{
+			"default_member_role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MEMBER",
+				ValidateFunc: validation.StringInSlice([]string{"MAINTAINER", "MEMBER"}, false),
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"is_default_team": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"members_can_create_pipelines": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"organization_slug": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Buildkite organization slug",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"privacy": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "VISIBLE",
+				ValidateFunc: validation.StringInSlice([]string{"SECRET", "VISIBLE"}, false),
+			},
+			"slug": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// Collect the team settings from Terraform into a GraphQL input object.
+func resourceTeamInput(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"defaultMemberRole":         enum(d.Get("default_member_role").(string)),
+		"description":               d.Get("description").(string),
+		"isDefaultTeam":             d.Get("is_default_team").(bool),
+		"membersCanCreatePipelines": d.Get("members_can_create_pipelines").(bool),
+		"name":                      d.Get("name").(string),
+		"privacy":                   enum(d.Get("privacy").(string)),
+	}
+}
+
 func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	slug := client.organization(d.Get("organization_slug").(string))
+	organizationID, err := client.readOrganizationID(slug)
+	if err != nil {
+		return err
+	}
+
+	input := resourceTeamInput(d)
+	input["organizationID"] = organizationID
+
+	team, err := client.createTeam(input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(team.ID)
+	d.Set("organization_slug", slug)
 	return resourceTeamRead(d, m)
 }
 
 func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	team, err := client.readTeam(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite team (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceState(d, resourceTeam(), team.convert(DataSourceFullEntity))
 }
 
 func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	input := resourceTeamInput(d)
+	input["id"] = d.Id()
+
+	if _, err := client.updateTeam(input); err != nil {
+		return err
+	}
 	return resourceTeamRead(d, m)
 }
 
 func resourceTeamDelete(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	err := client.deleteTeam(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a team by an "organization-slug/team-slug" identifier.
+func resourceTeamImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/team-slug")
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := client.readTeamBySlug(parts[0], parts[1])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no team found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(team.ID)
+	d.Set("organization_slug", parts[0])
+	return []*schema.ResourceData{d}, nil
 }
@@ -1,7 +1,12 @@
 package buildkite
 
 import (
+	"fmt"
+	"log"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceTeamMember() *schema.Resource {
@@ -10,28 +15,113 @@ func resourceTeamMember() *schema.Resource {
 		Read:   resourceTeamMemberRead,
 		Update: resourceTeamMemberUpdate,
 		Delete: resourceTeamMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamMemberImport,
+		},
 
 		Schema: map[string]*schema.Schema{
-			"address": &schema.Schema{
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MEMBER",
+				ValidateFunc: validation.StringInSlice([]string{"MAINTAINER", "MEMBER"}, false),
+			},
+			"team_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the team",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the user",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"uuid": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Computed: true,
 			},
 		},
 	}
 }
 
 func resourceTeamMemberCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	member, err := client.createTeamMember(map[string]interface{}{
+		"role":   enum(d.Get("role").(string)),
+		"teamID": d.Get("team_id").(string),
+		"userID": d.Get("user_id").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(member.ID)
 	return resourceTeamMemberRead(d, m)
 }
 
 func resourceTeamMemberRead(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	member, err := client.readTeamMember(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite team member (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"role":    member.Role,
+		"team_id": member.Team.ID,
+		"user_id": member.User.ID,
+		"uuid":    member.UUID,
+	})
 }
 
 func resourceTeamMemberUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	if _, err := client.updateTeamMember(d.Id(), d.Get("role").(string)); err != nil {
+		return err
+	}
 	return resourceTeamMemberRead(d, m)
 }
 
 func resourceTeamMemberDelete(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	err := client.deleteTeamMember(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a team member by an "organization-slug/team-slug/user-email" identifier.
+func resourceTeamMemberImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/team-slug/user-email")
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := client.readTeamMembers(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member.User.Email, parts[2]) {
+			d.SetId(member.ID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no team member found with ID (%s)", d.Id())
 }
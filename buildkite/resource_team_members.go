@@ -0,0 +1,160 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceTeamMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTeamMembersCreate,
+		Read:   resourceTeamMembersRead,
+		Update: resourceTeamMembersUpdate,
+		Delete: resourceTeamMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamMembersImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"member": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Complete set of members of the team; members not listed are removed",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "MEMBER",
+							ValidateFunc: validation.StringInSlice([]string{"MAINTAINER", "MEMBER"}, false),
+						},
+						"user_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "GraphQL identifier of the user",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"team_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the team",
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+// Collect the role of each member in a member block, by user ID.
+func resourceTeamMembersRoles(set *schema.Set) map[string]string {
+	roles := map[string]string{}
+	for _, item := range set.List() {
+		member := item.(map[string]interface{})
+		roles[member["user_id"].(string)] = member["role"].(string)
+	}
+	return roles
+}
+
+// Manage the members of a team as grants of a role to each user.
+func teamMemberGrants(client *Client, teamID string) grantReconciler {
+	return grantReconciler{
+		read: func() ([]grant, error) {
+			members, err := client.readTeamMembersByID(teamID)
+			if err != nil {
+				return nil, err
+			}
+			grants := []grant{}
+			for _, member := range members {
+				grants = append(grants, grant{id: member.ID, level: member.Role, subject: member.User.ID})
+			}
+			return grants, nil
+		},
+		create: func(userID string, role string) error {
+			_, err := client.createTeamMember(map[string]interface{}{
+				"role":   enum(role),
+				"teamID": teamID,
+				"userID": userID,
+			})
+			return err
+		},
+		update: func(id string, role string) error {
+			_, err := client.updateTeamMember(id, role)
+			return err
+		},
+		delete: client.deleteTeamMember,
+	}
+}
+
+func resourceTeamMembersCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	teamID := d.Get("team_id").(string)
+	roles := resourceTeamMembersRoles(d.Get("member").(*schema.Set))
+	if err := teamMemberGrants(client, teamID).apply(roles, allGrants); err != nil {
+		return err
+	}
+
+	d.SetId(teamID)
+	return resourceTeamMembersRead(d, m)
+}
+
+func resourceTeamMembersRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	grants, err := teamMemberGrants(client, d.Id()).read()
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite team (%s) not found, removing team members from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"member":  grantSet(grants, "role", "user_id", allGrants),
+		"team_id": d.Id(),
+	})
+}
+
+func resourceTeamMembersUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	roles := resourceTeamMembersRoles(d.Get("member").(*schema.Set))
+	if err := teamMemberGrants(client, d.Id()).apply(roles, allGrants); err != nil {
+		return err
+	}
+	return resourceTeamMembersRead(d, m)
+}
+
+func resourceTeamMembersDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	// Only remove the members this resource manages
+	return teamMemberGrants(client, d.Id()).revoke(resourceTeamMembersRoles(d.Get("member").(*schema.Set)))
+}
+
+// Import the members of a team by an "organization-slug/team-slug" identifier.
+func resourceTeamMembersImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/team-slug")
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := client.readTeamBySlug(parts[0], parts[1])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no team found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(team.ID)
+	return []*schema.ResourceData{d}, nil
+}
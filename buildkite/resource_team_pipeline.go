@@ -1,37 +1,129 @@
 package buildkite
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
+// Access levels a team can be granted on a pipeline.
+var teamPipelineAccessLevels = []string{"BUILD_AND_READ", "MANAGE_BUILD_AND_READ", "READ_ONLY"}
+
 func resourceTeamPipeline() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceTeamPipelineCreate,
 		Read:   resourceTeamPipelineRead,
 		Update: resourceTeamPipelineUpdate,
 		Delete: resourceTeamPipelineDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamPipelineImport,
+		},
 
 		Schema: map[string]*schema.Schema{
-			"address": &schema.Schema{
+			"access_level": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MANAGE_BUILD_AND_READ",
+				ValidateFunc: validation.StringInSlice(teamPipelineAccessLevels, false),
+			},
+			"pipeline_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the pipeline",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the team",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"uuid": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Computed: true,
 			},
 		},
 	}
 }
 
 func resourceTeamPipelineCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	teamPipeline, err := client.createTeamPipeline(map[string]interface{}{
+		"accessLevel": enum(d.Get("access_level").(string)),
+		"pipelineID":  d.Get("pipeline_id").(string),
+		"teamID":      d.Get("team_id").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(teamPipeline.ID)
 	return resourceTeamPipelineRead(d, m)
 }
 
 func resourceTeamPipelineRead(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	teamPipeline, err := client.readTeamPipeline(d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite team pipeline (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"access_level": teamPipeline.AccessLevel,
+		"pipeline_id":  teamPipeline.Pipeline.ID,
+		"team_id":      teamPipeline.Team.ID,
+		"uuid":         teamPipeline.UUID,
+	})
 }
 
 func resourceTeamPipelineUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	if _, err := client.updateTeamPipeline(d.Id(), d.Get("access_level").(string)); err != nil {
+		return err
+	}
 	return resourceTeamPipelineRead(d, m)
 }
 
 func resourceTeamPipelineDelete(d *schema.ResourceData, m interface{}) error {
-	return nil
+	client := m.(*Client)
+
+	err := client.deleteTeamPipeline(d.Id())
+	if isNotFound(err) {
+		// Already deleted outside of Terraform
+		return nil
+	}
+	return err
+}
+
+// Import a team pipeline by an "organization-slug/team-slug/pipeline-slug" identifier.
+func resourceTeamPipelineImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/team-slug/pipeline-slug")
+	if err != nil {
+		return nil, err
+	}
+
+	teamPipelines, err := client.readTeamPipelines(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, teamPipeline := range teamPipelines {
+		if teamPipeline.Pipeline.Slug == parts[2] {
+			d.SetId(teamPipeline.ID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no team pipeline found with ID (%s)", d.Id())
 }
@@ -0,0 +1,150 @@
+package buildkite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceTeamPipelines() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTeamPipelinesCreate,
+		Read:   resourceTeamPipelinesRead,
+		Update: resourceTeamPipelinesUpdate,
+		Delete: resourceTeamPipelinesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamPipelinesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "GraphQL identifier of the pipeline",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Complete set of teams granted access to the pipeline; other grants are revoked",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_level": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "MANAGE_BUILD_AND_READ",
+							ValidateFunc: validation.StringInSlice(teamPipelineAccessLevels, false),
+						},
+						"team_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "GraphQL identifier of the team",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Manage the teams of a pipeline as grants of an access level to each team.
+func pipelineTeamGrants(client *Client, pipelineID string) grantReconciler {
+	return grantReconciler{
+		read: func() ([]grant, error) {
+			teamPipelines, err := client.readPipelineTeams(pipelineID)
+			if err != nil {
+				return nil, err
+			}
+			grants := []grant{}
+			for _, teamPipeline := range teamPipelines {
+				grants = append(grants, grant{id: teamPipeline.ID, level: teamPipeline.AccessLevel, subject: teamPipeline.Team.ID})
+			}
+			return grants, nil
+		},
+		create: func(teamID string, accessLevel string) error {
+			_, err := client.createTeamPipeline(map[string]interface{}{
+				"accessLevel": enum(accessLevel),
+				"pipelineID":  pipelineID,
+				"teamID":      teamID,
+			})
+			return err
+		},
+		update: func(id string, accessLevel string) error {
+			_, err := client.updateTeamPipeline(id, accessLevel)
+			return err
+		},
+		delete: client.deleteTeamPipeline,
+	}
+}
+
+func resourceTeamPipelinesCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	pipelineID := d.Get("pipeline_id").(string)
+	accessLevels := resourcePipelineTeams(d.Get("team").(*schema.Set))
+	if err := pipelineTeamGrants(client, pipelineID).apply(accessLevels, allGrants); err != nil {
+		return err
+	}
+
+	d.SetId(pipelineID)
+	return resourceTeamPipelinesRead(d, m)
+}
+
+func resourceTeamPipelinesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	grants, err := pipelineTeamGrants(client, d.Id()).read()
+	if isNotFound(err) {
+		log.Printf("[WARN] Buildkite pipeline (%s) not found, removing team pipelines from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setResourceData(d, map[string]interface{}{
+		"pipeline_id": d.Id(),
+		"team":        grantSet(grants, "access_level", "team_id", allGrants),
+	})
+}
+
+func resourceTeamPipelinesUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	accessLevels := resourcePipelineTeams(d.Get("team").(*schema.Set))
+	if err := pipelineTeamGrants(client, d.Id()).apply(accessLevels, allGrants); err != nil {
+		return err
+	}
+	return resourceTeamPipelinesRead(d, m)
+}
+
+func resourceTeamPipelinesDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	// Only revoke the grants this resource manages
+	return pipelineTeamGrants(client, d.Id()).revoke(resourcePipelineTeams(d.Get("team").(*schema.Set)))
+}
+
+// Import the team grants of a pipeline by an "organization-slug/pipeline-slug" identifier.
+func resourceTeamPipelinesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*Client)
+
+	parts, err := parseImportID(d.Id(), "organization-slug/pipeline-slug")
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := client.readPipelineBySlug(parts[0], parts[1])
+	if isNotFound(err) {
+		return nil, fmt.Errorf("no pipeline found with ID (%s)", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(pipeline.ID)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,610 @@
+package buildkite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// The kinds of value a step attribute accepts.
+type stepValue int
+
+const (
+	stepValueAny stepValue = iota
+	stepValueBool
+	stepValueInt
+	stepValueMap
+	stepValueString
+	stepValueStringOrList
+	stepValueBoolOrList
+	stepValueBoolOrString
+	stepValueList
+	stepValueDependencies
+	stepValuePlugins
+	stepValueSteps
+)
+
+// Attributes that every type of step accepts.
+var stepCommonAttributes = map[string]stepValue{
+	"allow_dependency_failure": stepValueBool,
+	"branches":                 stepValueStringOrList,
+	"depends_on":               stepValueDependencies,
+	"id":                       stepValueString,
+	"identifier":               stepValueString,
+	"if":                       stepValueString,
+	"key":                      stepValueString,
+	"type":                     stepValueString,
+}
+
+// Attributes known for each type of step, on top of the common attributes.
+// This is a hand-maintained list of the attributes Buildkite documents rather
+// than the published pipeline schema, so it must be extended as Buildkite adds
+// attributes; until then a new attribute is reported as unknown.
+var stepAttributes = map[string]map[string]stepValue{
+	"block": {
+		"block":         stepValueString,
+		"blocked_state": stepValueString,
+		"fields":        stepValueList,
+		"label":         stepValueString,
+		"manual":        stepValueString,
+		"name":          stepValueString,
+		"prompt":        stepValueString,
+	},
+	"command": {
+		"agents":                  stepValueAny,
+		"artifact_paths":          stepValueStringOrList,
+		"cache":                   stepValueAny,
+		"cancel_on_build_failing": stepValueBool,
+		"command":                 stepValueStringOrList,
+		"commands":                stepValueStringOrList,
+		"concurrency":             stepValueInt,
+		"concurrency_group":       stepValueString,
+		"concurrency_method":      stepValueString,
+		"env":                     stepValueMap,
+		"label":                   stepValueString,
+		"matrix":                  stepValueAny,
+		"name":                    stepValueString,
+		"notify":                  stepValueList,
+		"parallelism":             stepValueInt,
+		"plugins":                 stepValuePlugins,
+		"priority":                stepValueInt,
+		"retry":                   stepValueMap,
+		"script":                  stepValueStringOrList,
+		"secrets":                 stepValueAny,
+		"signature":               stepValueMap,
+		"skip":                    stepValueBoolOrString,
+		"soft_fail":               stepValueBoolOrList,
+		"timeout_in_minutes":      stepValueInt,
+	},
+	"group": {
+		"group":  stepValueString,
+		"label":  stepValueString,
+		"name":   stepValueString,
+		"notify": stepValueList,
+		"skip":   stepValueBoolOrString,
+		"steps":  stepValueSteps,
+	},
+	"input": {
+		"fields": stepValueList,
+		"input":  stepValueString,
+		"label":  stepValueString,
+		"name":   stepValueString,
+		"prompt": stepValueString,
+	},
+	"trigger": {
+		"async":     stepValueBool,
+		"build":     stepValueMap,
+		"label":     stepValueString,
+		"name":      stepValueString,
+		"skip":      stepValueBoolOrString,
+		"soft_fail": stepValueBoolOrList,
+		"trigger":   stepValueString,
+	},
+	"wait": {
+		"continue_on_failure": stepValueBool,
+		"wait":                stepValueAny,
+		"waiter":              stepValueAny,
+	},
+}
+
+// Attributes checked at the top level of a pipeline definition; any other
+// top-level keys are allowed, such as those holding YAML anchors.
+var pipelineAttributes = map[string]stepValue{
+	"agents":  stepValueAny,
+	"env":     stepValueMap,
+	"image":   stepValueString,
+	"notify":  stepValueList,
+	"secrets": stepValueAny,
+	"steps":   stepValueSteps,
+}
+
+// Step attributes that identify the type of a step, and aliases for types.
+var stepTypeAttributes = map[string]string{
+	"block":    "block",
+	"command":  "command",
+	"commands": "command",
+	"group":    "group",
+	"input":    "input",
+	"manual":   "block",
+	"script":   "command",
+	"trigger":  "trigger",
+	"wait":     "wait",
+	"waiter":   "wait",
+}
+
+// Plugin references look like "org/name#version", "name", or a repository URL.
+var pluginReference = regexp.MustCompile(`^[\w.@:/~+-]+(#[\w.@/+-]+)?$`)
+
+// Collects the problems found while validating a pipeline definition.
+type stepsValidator struct {
+	errors     []string
+	keys       map[string]int
+	dependsOn  []*yaml.Node
+	dynamicRun bool
+}
+
+// Record a problem with the definition at the line of the given YAML node.
+func (v *stepsValidator) fail(node *yaml.Node, format string, args ...interface{}) {
+	v.errors = append(v.errors, fmt.Sprintf("line %d: %s", node.Line, fmt.Sprintf(format, args...)))
+}
+
+// Validate pipeline steps YAML against the known step types and attributes,
+// returning one message per problem, prefixed by its line number. Unknown
+// top-level keys are allowed, as they commonly hold YAML anchors.
+func validatePipelineStepsYAML(source string) []string {
+	// Decoding fully first reports aliases that refer to themselves
+	var decoded interface{}
+	if err := decodeSingleYAMLDocument(source, &decoded); err != nil && err != io.EOF {
+		return []string{err.Error()}
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &document); err != nil {
+		return []string{err.Error()}
+	}
+	if len(document.Content) == 0 {
+		return []string{"pipeline definition is empty"}
+	}
+
+	v := &stepsValidator{keys: map[string]int{}}
+	root := resolveYAMLAliases(document.Content[0])
+	switch root.Kind {
+	case yaml.SequenceNode:
+		v.steps(root, false)
+	case yaml.MappingNode:
+		v.known(root, pipelineAttributes, false)
+		if mappingValue(root, "steps") == nil {
+			v.fail(root, "pipeline definition must contain steps")
+		}
+	default:
+		v.fail(root, "pipeline definition must be a list of steps or a map containing steps")
+	}
+
+	// Steps added by a dynamic pipeline upload may satisfy dependencies later
+	if !v.dynamicRun {
+		for _, node := range v.dependsOn {
+			if _, ok := v.keys[node.Value]; !ok {
+				v.fail(node, "depends_on refers to unknown step key %q", node.Value)
+			}
+		}
+	}
+	return v.errors
+}
+
+// Decode YAML that must hold at most one document, returning io.EOF when it holds none.
+func decodeSingleYAMLDocument(source string, result interface{}) error {
+	decoder := yaml.NewDecoder(strings.NewReader(source))
+	if err := decoder.Decode(result); err != nil {
+		return err
+	}
+	var extra yaml.Node
+	if err := decoder.Decode(&extra); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("line %d: pipeline steps must be a single YAML document", extra.Line)
+	}
+	return nil
+}
+
+// Expand YAML aliases and merge keys, as Buildkite does before reading a
+// pipeline, keeping the line numbers of the nodes as written.
+func resolveYAMLAliases(node *yaml.Node) *yaml.Node {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return resolveYAMLAliases(node.Alias)
+	case yaml.SequenceNode:
+		resolved := *node
+		resolved.Content = make([]*yaml.Node, len(node.Content))
+		for ix, item := range node.Content {
+			resolved.Content[ix] = resolveYAMLAliases(item)
+		}
+		return &resolved
+	case yaml.MappingNode:
+		resolved := *node
+		resolved.Content = nil
+		seen := map[string]bool{}
+		var merged []*yaml.Node
+		for ix := 0; ix+1 < len(node.Content); ix += 2 {
+			key, value := node.Content[ix], resolveYAMLAliases(node.Content[ix+1])
+			if key.Tag == "!!merge" {
+				if value.Kind == yaml.SequenceNode {
+					merged = append(merged, value.Content...)
+				} else {
+					merged = append(merged, value)
+				}
+				continue
+			}
+			seen[key.Value] = true
+			resolved.Content = append(resolved.Content, key, value)
+		}
+
+		// Keys written in the map win over merged keys, and earlier merged maps over later ones
+		for _, source := range merged {
+			if source.Kind != yaml.MappingNode {
+				continue
+			}
+			for ix := 0; ix+1 < len(source.Content); ix += 2 {
+				if key := source.Content[ix]; !seen[key.Value] {
+					seen[key.Value] = true
+					resolved.Content = append(resolved.Content, key, source.Content[ix+1])
+				}
+			}
+		}
+		return &resolved
+	default:
+		return node
+	}
+}
+
+// Validate a list of steps; groups may only appear at the top level.
+func (v *stepsValidator) steps(node *yaml.Node, nested bool) {
+	if node.Kind != yaml.SequenceNode {
+		v.fail(node, "steps must be a list")
+		return
+	}
+	for _, step := range node.Content {
+		v.step(step, nested)
+	}
+}
+
+// Validate a single step of any type.
+func (v *stepsValidator) step(node *yaml.Node, nested bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		switch node.Value {
+		case "block", "input", "manual", "wait", "waiter":
+		default:
+			v.fail(node, "unknown step %q", node.Value)
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		v.fail(node, "step must be a map or one of \"wait\", \"block\" or \"input\"")
+		return
+	}
+
+	stepType := ""
+	if typeNode := mappingValue(node, "type"); typeNode != nil {
+		stepType = stepTypeAttributes[typeNode.Value]
+		if stepType == "" {
+			v.fail(typeNode, "unknown step type %q", typeNode.Value)
+			return
+		}
+	}
+	for ix := 0; ix < len(node.Content); ix += 2 {
+		if t, ok := stepTypeAttributes[node.Content[ix].Value]; ok {
+			if stepType != "" && stepType != t {
+				v.fail(node.Content[ix], "step mixes attributes of %s and %s steps", stepType, t)
+				return
+			}
+			stepType = t
+		}
+	}
+	if stepType == "" {
+		if mappingValue(node, "plugins") == nil {
+			v.fail(node, "unable to determine the type of step; expected one of command, wait, block, input, trigger or group")
+			return
+		}
+		stepType = "command"
+	}
+	if stepType == "group" && nested {
+		v.fail(node, "group steps cannot be nested inside other groups")
+	}
+
+	attributes := map[string]stepValue{}
+	for key, value := range stepCommonAttributes {
+		attributes[key] = value
+	}
+	for key, value := range stepAttributes[stepType] {
+		attributes[key] = value
+	}
+	v.attributes(node, stepType+" step", attributes, true)
+
+	for _, key := range []string{"key", "id", "identifier"} {
+		if keyNode := mappingValue(node, key); keyNode != nil && keyNode.Kind == yaml.ScalarNode {
+			if line, ok := v.keys[keyNode.Value]; ok {
+				v.fail(keyNode, "step key %q is already used on line %d", keyNode.Value, line)
+			} else {
+				v.keys[keyNode.Value] = keyNode.Line
+			}
+		}
+	}
+	if stepType == "group" && mappingValue(node, "steps") == nil {
+		v.fail(node, "group step must contain steps")
+	}
+	if stepType == "command" {
+		for _, key := range []string{"command", "commands", "script"} {
+			if commandNode := mappingValue(node, key); commandNode != nil && strings.Contains(nodeText(commandNode), "pipeline upload") {
+				v.dynamicRun = true
+			}
+		}
+	}
+}
+
+// Validate the attributes of a map against the known attributes and value
+// kinds, rejecting attributes that are not known.
+func (v *stepsValidator) attributes(node *yaml.Node, kind string, attributes map[string]stepValue, nested bool) {
+	for ix := 0; ix < len(node.Content); ix += 2 {
+		keyNode := node.Content[ix]
+		if _, ok := attributes[keyNode.Value]; !ok {
+			v.fail(keyNode, "unknown attribute %q for %s; expected one of %s", keyNode.Value, kind, attributeNames(attributes))
+		}
+	}
+	v.known(node, attributes, nested)
+}
+
+// Validate the values of the known attributes of a map, ignoring any others.
+func (v *stepsValidator) known(node *yaml.Node, attributes map[string]stepValue, nested bool) {
+	for ix := 0; ix < len(node.Content); ix += 2 {
+		keyNode, valueNode := node.Content[ix], node.Content[ix+1]
+		if valueKind, ok := attributes[keyNode.Value]; ok {
+			v.value(keyNode.Value, valueNode, valueKind, nested)
+		}
+	}
+}
+
+// Validate a single attribute value against the kind of value it accepts.
+func (v *stepsValidator) value(name string, node *yaml.Node, kind stepValue, nested bool) {
+	switch kind {
+	case stepValueBool:
+		if !isScalar(node, "!!bool") {
+			v.fail(node, "%s must be true or false", name)
+		}
+	case stepValueInt:
+		if !isScalar(node, "!!int") && !isInterpolated(node) && !isQuotedInt(node) {
+			v.fail(node, "%s must be an integer", name)
+		}
+	case stepValueMap:
+		if node.Kind != yaml.MappingNode {
+			v.fail(node, "%s must be a map", name)
+		}
+	case stepValueString:
+		if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+			v.fail(node, "%s must be a string", name)
+		}
+	case stepValueStringOrList:
+		if node.Kind == yaml.SequenceNode {
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode {
+					v.fail(item, "%s must be a string or a list of strings", name)
+				}
+			}
+		} else if node.Kind != yaml.ScalarNode {
+			v.fail(node, "%s must be a string or a list of strings", name)
+		}
+	case stepValueBoolOrList:
+		if node.Kind != yaml.SequenceNode && !isScalar(node, "!!bool") {
+			v.fail(node, "%s must be true, false or a list", name)
+		}
+	case stepValueBoolOrString:
+		if node.Kind != yaml.ScalarNode {
+			v.fail(node, "%s must be true, false or a string", name)
+		}
+	case stepValueList:
+		if node.Kind != yaml.SequenceNode {
+			v.fail(node, "%s must be a list", name)
+		}
+	case stepValueDependencies:
+		v.dependencies(node)
+	case stepValuePlugins:
+		v.plugins(node)
+	case stepValueSteps:
+		v.steps(node, nested)
+	}
+}
+
+// Validate depends_on, which is a key, a list of keys, or a list of {step, allow_failure} maps.
+func (v *stepsValidator) dependencies(node *yaml.Node) {
+	var items []*yaml.Node
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!null" {
+			items = []*yaml.Node{node}
+		}
+	case yaml.SequenceNode:
+		items = node.Content
+	default:
+		v.fail(node, "depends_on must be a step key or a list of step keys")
+		return
+	}
+
+	for _, item := range items {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			v.dependsOn = append(v.dependsOn, item)
+		case yaml.MappingNode:
+			v.attributes(item, "depends_on entry", map[string]stepValue{
+				"allow_failure": stepValueBool,
+				"step":          stepValueString,
+			}, false)
+			if stepNode := mappingValue(item, "step"); stepNode != nil {
+				v.dependsOn = append(v.dependsOn, stepNode)
+			} else {
+				v.fail(item, "depends_on entry must contain step")
+			}
+		default:
+			v.fail(item, "depends_on entry must be a step key or a map with step")
+		}
+	}
+}
+
+// Validate plugins, either as a list of references and single-entry maps or as a map.
+func (v *stepsValidator) plugins(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			switch item.Kind {
+			case yaml.ScalarNode:
+				v.pluginReference(item)
+			case yaml.MappingNode:
+				if len(item.Content) != 2 {
+					v.fail(item, "each plugin must be a map with a single plugin reference as its key")
+					continue
+				}
+				v.pluginReference(item.Content[0])
+				v.pluginConfiguration(item.Content[1])
+			default:
+				v.fail(item, "each plugin must be a plugin reference or a map of a reference to its configuration")
+			}
+		}
+	case yaml.MappingNode:
+		for ix := 0; ix < len(node.Content); ix += 2 {
+			v.pluginReference(node.Content[ix])
+			v.pluginConfiguration(node.Content[ix+1])
+		}
+	default:
+		v.fail(node, "plugins must be a list or a map")
+	}
+}
+
+// Validate the reference to a plugin, such as "docker-compose#v3.0.0".
+func (v *stepsValidator) pluginReference(node *yaml.Node) {
+	if !pluginReference.MatchString(node.Value) {
+		v.fail(node, "invalid plugin reference %q", node.Value)
+	}
+}
+
+// Validate the configuration of a plugin, which is a map or empty.
+func (v *stepsValidator) pluginConfiguration(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode && node.Tag != "!!null" {
+		v.fail(node, "plugin configuration must be a map")
+	}
+}
+
+// Find the value of a key in a YAML map node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for ix := 0; ix < len(node.Content); ix += 2 {
+		if node.Content[ix].Value == key {
+			return node.Content[ix+1]
+		}
+	}
+	return nil
+}
+
+// Report whether a YAML node is a scalar with the given resolved tag.
+func isScalar(node *yaml.Node, tag string) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == tag
+}
+
+// Report whether a YAML scalar is a quoted integer, which Buildkite also accepts.
+func isQuotedInt(node *yaml.Node) bool {
+	if !isScalar(node, "!!str") {
+		return false
+	}
+	_, err := strconv.Atoi(node.Value)
+	return err == nil
+}
+
+// Report whether a YAML scalar contains an environment variable interpolation.
+func isInterpolated(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "$")
+}
+
+// Flatten the scalar text in a YAML node.
+func nodeText(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	var parts []string
+	for _, child := range node.Content {
+		parts = append(parts, nodeText(child))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// List the accepted attribute names in sorted order for error messages.
+func attributeNames(attributes map[string]stepValue) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Validate the steps attribute of a pipeline at plan time.
+func validatePipelineSteps(value interface{}, key string) (warnings []string, errors []error) {
+	for _, message := range validatePipelineStepsYAML(value.(string)) {
+		errors = append(errors, fmt.Errorf("%s: %s", key, message))
+	}
+	return
+}
+
+// Render pipeline steps YAML in a canonical form, with sorted keys and
+// consistent indentation and quoting, so only semantic changes are visible.
+func canonicalPipelineSteps(source string) (string, error) {
+	var document interface{}
+	err := decodeSingleYAMLDocument(source, &document)
+	if err == io.EOF {
+		// Nothing to canonicalise, rather than a null document
+		return source, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// Store pipeline steps in state in their canonical form; steps that do not
+// parse are kept as written so validation can report the problem.
+func normalizePipelineSteps(value interface{}) string {
+	source := value.(string)
+	canonical, err := canonicalPipelineSteps(source)
+	if err != nil {
+		return source
+	}
+	return canonical
+}
+
+// Suppress differences between pipeline steps that parse to the same document.
+func suppressEquivalentPipelineSteps(k, old, new string, d *schema.ResourceData) bool {
+	oldCanonical, err := canonicalPipelineSteps(old)
+	if err != nil {
+		return false
+	}
+	newCanonical, err := canonicalPipelineSteps(new)
+	if err != nil {
+		return false
+	}
+	return oldCanonical == newCanonical
+}
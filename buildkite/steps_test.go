@@ -0,0 +1,343 @@
+package buildkite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidatePipelineStepsYAML(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		errors []string
+	}{
+		{
+			name:   "default steps",
+			source: defaultPipelineSteps,
+		},
+		{
+			name: "top-level anchor keys",
+			source: `
+common: &docker
+  docker#v5.0.0:
+    image: golang
+x-defaults:
+  timeout_in_minutes: 10
+steps:
+  - command: make
+    plugins:
+      - *docker
+`,
+		},
+		{
+			name: "merge keys",
+			source: `
+x-defaults: &defaults
+  agents:
+    queue: build
+  timeout_in_minutes: 10
+steps:
+  - <<: *defaults
+    command: make
+  - <<: [*defaults]
+    command: make test
+`,
+		},
+		{
+			name: "merged values are validated",
+			source: `
+x-defaults: &defaults
+  timeout_in_minutes: soon
+steps:
+  - <<: *defaults
+    command: make
+`,
+			errors: []string{"line 3: timeout_in_minutes must be an integer"},
+		},
+		{
+			name: "top-level image",
+			source: `
+image: golang:1.14
+steps:
+  - command: make
+`,
+		},
+		{
+			name: "cache and secrets",
+			source: `
+steps:
+  - command: make
+    cache: node_modules
+    secrets:
+      - API_TOKEN
+`,
+		},
+		{
+			name: "quoted integer",
+			source: `
+steps:
+  - command: make
+    timeout_in_minutes: "10"
+`,
+		},
+		{
+			name: "unknown step attribute",
+			source: `
+steps:
+  - command: make
+    comand: make
+`,
+			errors: []string{`line 4: unknown attribute "comand" for command step`},
+		},
+		{
+			name: "unknown dependency",
+			source: `
+steps:
+  - command: make
+    key: build
+  - command: make test
+    depends_on: [build, lint]
+`,
+			errors: []string{`line 6: depends_on refers to unknown step key "lint"`},
+		},
+		{
+			name: "nested group",
+			source: `
+steps:
+  - group: outer
+    steps:
+      - group: inner
+        steps:
+          - command: make
+`,
+			errors: []string{"line 5: group steps cannot be nested inside other groups"},
+		},
+		{
+			name:   "empty",
+			source: "",
+			errors: []string{"pipeline definition is empty"},
+		},
+		{
+			name: "missing steps",
+			source: `
+env:
+  FOO: bar
+`,
+			errors: []string{"line 2: pipeline definition must contain steps"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertMessages(t, "errors", validatePipelineStepsYAML(c.source), c.errors)
+		})
+	}
+}
+
+// Check that each expected message prefixes exactly one of the actual messages, in order.
+func assertMessages(t *testing.T, kind string, actual []string, expected []string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d %s, got %q", len(expected), kind, actual)
+	}
+	for ix := range expected {
+		if !strings.HasPrefix(actual[ix], expected[ix]) {
+			t.Errorf("expected %s %d to start with %q, got %q", kind, ix, expected[ix], actual[ix])
+		}
+	}
+}
+
+func TestResolveYAMLAliases(t *testing.T) {
+	source := `
+base: &base
+  a: base
+  b: base
+other: &other
+  b: other
+  c: other
+merged:
+  <<: [*base, *other]
+  a: merged
+`
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	var merged map[string]string
+	if err := mappingValue(resolveYAMLAliases(document.Content[0]), "merged").Decode(&merged); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"a": "merged", "b": "base", "c": "other"}
+	for key, value := range expected {
+		if merged[key] != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, merged[key])
+		}
+	}
+	if len(merged) != len(expected) {
+		t.Errorf("expected %d keys, got %v", len(expected), merged)
+	}
+}
+
+func TestCanonicalPipelineSteps(t *testing.T) {
+	cases := []struct {
+		name      string
+		source    string
+		canonical string
+		error     string
+	}{
+		{
+			name:      "formatting",
+			source:    "steps:\n    - label: 'x'\n      command: \"make\"\n    - wait\n",
+			canonical: "steps:\n  - command: make\n    label: x\n  - wait\n",
+		},
+		{
+			name:      "empty",
+			source:    "",
+			canonical: "",
+		},
+		{
+			name:      "comments only",
+			source:    "# nothing yet\n",
+			canonical: "# nothing yet\n",
+		},
+		{
+			name:   "multiple documents",
+			source: "steps:\n  - command: make\n---\nsteps:\n  - command: make test\n",
+			error:  "line 3: pipeline steps must be a single YAML document",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			canonical, err := canonicalPipelineSteps(c.source)
+			if c.error != "" {
+				if err == nil || err.Error() != c.error {
+					t.Fatalf("expected error %q, got %v", c.error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if canonical != c.canonical {
+				t.Errorf("expected %q, got %q", c.canonical, canonical)
+			}
+		})
+	}
+}
+
+func TestRenderPipelineSteps(t *testing.T) {
+	cases := []struct {
+		name  string
+		steps []interface{}
+		yaml  string
+		error string
+	}{
+		{
+			name: "wait only",
+			steps: []interface{}{
+				map[string]interface{}{"wait": []interface{}{map[string]interface{}{}}},
+			},
+			yaml: "steps:\n  - wait: null\n",
+		},
+		{
+			name: "group nesting",
+			steps: []interface{}{
+				map[string]interface{}{"group": []interface{}{map[string]interface{}{
+					"label": "Tests",
+					"step": []interface{}{
+						map[string]interface{}{"command": []interface{}{map[string]interface{}{
+							"commands": []interface{}{"make test"},
+							"label":    "Unit",
+						}}},
+						map[string]interface{}{"wait": []interface{}{map[string]interface{}{"continue_on_failure": true}}},
+					},
+				}}},
+			},
+			yaml: "steps:\n  - group: Tests\n    steps:\n      - commands:\n          - make test\n        label: Unit\n      - continue_on_failure: true\n        wait: null\n",
+		},
+		{
+			name: "select fields",
+			steps: []interface{}{
+				map[string]interface{}{"block": []interface{}{map[string]interface{}{
+					"label": "Release",
+					"field": []interface{}{
+						map[string]interface{}{"key": "notes", "text": "Release notes", "required": false},
+						map[string]interface{}{
+							"key":  "stage",
+							"text": "Stage",
+							"option": []interface{}{
+								map[string]interface{}{"label": "Staging", "value": "staging"},
+								map[string]interface{}{"label": "Production", "value": "production"},
+							},
+						},
+					},
+				}}},
+			},
+			yaml: "steps:\n  - block: Release\n    fields:\n      - key: notes\n        required: false\n        text: Release notes\n      - key: stage\n        options:\n          - label: Staging\n            value: staging\n          - label: Production\n            value: production\n        required: true\n        select: Stage\n",
+		},
+		{
+			name: "retry",
+			steps: []interface{}{
+				map[string]interface{}{"command": []interface{}{map[string]interface{}{
+					"commands": []interface{}{"make"},
+					"retry": []interface{}{map[string]interface{}{
+						"automatic": []interface{}{
+							map[string]interface{}{"exit_status": "-1", "limit": 3},
+							map[string]interface{}{},
+						},
+						"manual_allowed": false,
+						"manual_reason":  "Deploys are not idempotent",
+					}},
+				}}},
+			},
+			yaml: "steps:\n  - commands:\n      - make\n    retry:\n      automatic:\n        - exit_status: -1\n          limit: 3\n        - exit_status: '*'\n          limit: 2\n      manual:\n        allowed: false\n        reason: Deploys are not idempotent\n",
+		},
+		{
+			name: "invalid plugin configuration",
+			steps: []interface{}{
+				map[string]interface{}{"wait": []interface{}{map[string]interface{}{}}},
+				map[string]interface{}{"command": []interface{}{map[string]interface{}{
+					"commands": []interface{}{"make"},
+					"plugin": []interface{}{map[string]interface{}{
+						"name":          "docker#v5.0.0",
+						"configuration": "image: [golang",
+					}},
+				}}},
+			},
+			error: "step 2: configuration of plugin \"docker#v5.0.0\" is not valid YAML or JSON",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourcePipelineSteps().Schema, map[string]interface{}{"step": c.steps})
+			steps, err := renderPipelineSteps(d.Get("step").([]interface{}))
+			if c.error != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), c.error) {
+					t.Fatalf("expected error %q, got %v", c.error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rendered, err := yaml.Marshal(map[string]interface{}{"steps": steps})
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonical, err := canonicalPipelineSteps(string(rendered))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if canonical != c.yaml {
+				t.Errorf("expected %q, got %q", c.yaml, canonical)
+			}
+		})
+	}
+}